@@ -0,0 +1,140 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package build
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/cmd/bob/report"
+	"github.com/megakuul/bob/cmd/bob/workspace"
+	"github.com/megakuul/bob/internal/processor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewBuildCmd(options *BuildOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "build",
+		Short:        "Build a target without executing it",
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Run(args); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+	options.AttachFlags(cmd.Flags())
+
+	return cmd
+}
+
+type BuildOptions struct {
+	globalFlags *flags.GlobalFlags
+	output string
+	clean bool
+	jobs int
+	keepGoing bool
+	profile string
+}
+
+func NewBuildOptions(gFlags *flags.GlobalFlags) *BuildOptions {
+	return &BuildOptions{
+		globalFlags: gFlags,
+	}
+}
+
+func (b *BuildOptions) AttachFlags(flagSet *pflag.FlagSet) {
+	flagSet.StringVarP(&b.output, "output", "o", "", "directory where the final artifacts are placed")
+	flagSet.BoolVarP(&b.clean, "clean", "c", false, "cleanup cache before execution") 
+	flagSet.IntVar(&b.jobs, "jobs", runtime.NumCPU(), "maximum number of parallel compile and link jobs")
+	flagSet.BoolVarP(&b.keepGoing, "keep-going", "k", false, "continue compiling independent packs after a failure")
+	flagSet.StringVar(&b.profile, "profile", "", "profile (e.g. debug, release) whose flags are used for the build")
+}
+
+func (b *BuildOptions) Run(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly '%d' argument got '%d'", 1, len(args))
+	}
+
+	output, err := b.Build(args[0])
+	if err!=nil {
+		return err
+	}
+	// placed artifacts are already reported by Build().
+	if b.output == "" {
+		b.report(args[0], output)
+	}
+	return nil
+}
+
+// report prints the path of the built artifacts.
+func (b *BuildOptions) report(target string, output *processor.Output) {
+	report.NewReporter(b.globalFlags.Json).Info("target built", "target", target, "path", output.Path)
+}
+
+// Build builds the target. If an output directory is specified, the artifacts are placed there and their
+// path is reported.
+func (b *BuildOptions) Build(target string) (*processor.Output, error) {
+	if b.jobs < 1 {
+		return nil, fmt.Errorf("expected at least '%d' job got '%d'", 1, b.jobs)
+	}
+
+	ws, err := workspace.Open(b.globalFlags)
+	if err!=nil {
+		return nil, err
+	}
+
+	profile, err := ws.Mod.Profile(b.profile)
+	if err!=nil {
+		return nil, err
+	}
+
+	proc := ws.NewProcessor(
+		processor.WithClean(b.clean),
+		processor.WithJobs(b.jobs),
+		processor.WithKeepGoing(b.keepGoing),
+		processor.WithProfile(profile),
+	)
+
+	output, err := proc.BuildTarget(ws.Mod, ws.ModPath, target)
+	if closeErr := ws.Close(); closeErr!=nil {
+		return nil, closeErr
+	}
+	if err!=nil {
+		return nil, err
+	}
+	slog.Debug(fmt.Sprintf("target '%s' built at '%s'", target, output.Path))
+
+	if b.output != "" {
+		output.Path, err = processor.Install(output, b.output)
+		if err!=nil {
+			return nil, fmt.Errorf("cannot place artifacts in '%s': %w", b.output, err)
+		}
+		b.report(target, output)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,139 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package compdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/cmd/bob/report"
+	"github.com/megakuul/bob/cmd/bob/workspace"
+	"github.com/megakuul/bob/internal/processor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const COMPDB_FILE_NAME = "compile_commands.json"
+
+func NewCompdbCmd(options *CompdbOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "compdb [target...]",
+		Short:        "Generate a compilation database (compile_commands.json) for editor tooling",
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Run(args); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+	options.AttachFlags(cmd.Flags())
+
+	return cmd
+}
+
+type CompdbOptions struct {
+	globalFlags *flags.GlobalFlags
+	output string
+	profile string
+}
+
+func NewCompdbOptions(gFlags *flags.GlobalFlags) *CompdbOptions {
+	return &CompdbOptions{
+		globalFlags: gFlags,
+	}
+}
+
+func (c *CompdbOptions) AttachFlags(flagSet *pflag.FlagSet) {
+	flagSet.StringVarP(&c.output, "output", "o", "", "path of the compilation database (defaults to the module directory)")
+	flagSet.StringVar(&c.profile, "profile", "", "profile whose flags are used in the compile commands")
+}
+
+// Run writes the compile commands of all packs reachable from the targets (or all targets of the module if none
+// is specified) to the compilation database. Sources shared by multiple targets are listed once.
+func (c *CompdbOptions) Run(targets []string) error {
+	ws, err := workspace.Open(c.globalFlags)
+	if err!=nil {
+		return err
+	}
+
+	profile, err := ws.Mod.Profile(c.profile)
+	if err!=nil {
+		return err
+	}
+	if len(targets) < 1 {
+		targets = slices.Sorted(maps.Keys(ws.Mod.Targets))
+	}
+
+	proc := ws.NewProcessor(processor.WithProfile(profile))
+	commands := []*processor.CompileCommand{}
+	files := map[string]bool{}
+	for _, target := range targets {
+		targetCommands, err := proc.CompileCommands(ws.Mod, ws.ModPath, target)
+		if err!=nil {
+			ws.Close()
+			return fmt.Errorf("failed to resolve compile commands of target '%s': %w", target, err)
+		}
+		for _, command := range targetCommands {
+			if !files[command.File] {
+				files[command.File] = true
+				commands = append(commands, command)
+			}
+		}
+	}
+	if err:=ws.Close(); err!=nil {
+		return err
+	}
+
+	path := c.output
+	if path == "" {
+		path = filepath.Join(ws.ModPath, COMPDB_FILE_NAME)
+	}
+	if err:=writeCompdb(path, commands); err!=nil {
+		return fmt.Errorf("cannot write compilation database: %w", err)
+	}
+
+	report.NewReporter(c.globalFlags.Json).Info("compilation database written", "path", path, "entries", len(commands))
+	return nil
+}
+
+// writeCompdb writes the compile commands as json array to the path.
+func writeCompdb(path string, commands []*processor.CompileCommand) error {
+	if err:=os.MkdirAll(filepath.Dir(path), 0755); err!=nil {
+		return err
+	}
+	compdbFile, err := os.Create(path)
+	if err!=nil {
+		return err
+	}
+	defer compdbFile.Close()
+
+	encoder := json.NewEncoder(compdbFile)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(commands)
+}
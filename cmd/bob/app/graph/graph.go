@@ -0,0 +1,290 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/cmd/bob/workspace"
+	depgraph "github.com/megakuul/bob/internal/graph"
+	"github.com/megakuul/bob/internal/processor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type FORMAT string
+
+const (
+	FORMAT_DOT FORMAT = "dot"
+	FORMAT_MERMAID FORMAT = "mermaid"
+	FORMAT_JSON FORMAT = "json"
+)
+
+var FORMATS = map[string]FORMAT{
+	"dot": FORMAT_DOT,
+	"mermaid": FORMAT_MERMAID,
+	"json": FORMAT_JSON,
+}
+
+func NewGraphCmd(options *GraphOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "graph <target>",
+		Short:        "Print the dependency graph of a target as dot, mermaid or json",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Run(args[0]); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+	options.AttachFlags(cmd.Flags())
+
+	return cmd
+}
+
+type GraphOptions struct {
+	globalFlags *flags.GlobalFlags
+	format string
+	depth int
+	externalsOnly bool
+}
+
+func NewGraphOptions(gFlags *flags.GlobalFlags) *GraphOptions {
+	return &GraphOptions{
+		globalFlags: gFlags,
+	}
+}
+
+func (g *GraphOptions) AttachFlags(flagSet *pflag.FlagSet) {
+	flagSet.StringVarP(&g.format, "format", "f", string(FORMAT_DOT), "output format (dot, mermaid, json)")
+	flagSet.IntVar(&g.depth, "depth", 0, "only show nodes up to this distance from the target (0 shows all)")
+	flagSet.BoolVar(&g.externalsOnly, "externals-only", false, "only show externals and the packs depending on them")
+}
+
+// node is a pack, external or toolchain in the printed graph.
+type node struct {
+	ID string `json:"id"`
+	Kind string `json:"kind"`
+	Module string `json:"module,omitempty"`
+}
+
+// edge is a dependency ('dep') or the toolchain used by a pack ('toolchain') in the printed graph.
+type edge struct {
+	From string `json:"from"`
+	To string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// view is the filtered graph in the form it is printed.
+type view struct {
+	Root string `json:"root"`
+	Nodes []node `json:"nodes"`
+	Edges []edge `json:"edges"`
+}
+
+func (g *GraphOptions) Run(target string) error {
+	format, ok := FORMATS[g.format]
+	if !ok {
+		return fmt.Errorf("unknown format '%s'", g.format)
+	}
+	if g.globalFlags.Json {
+		format = FORMAT_JSON
+	}
+
+	ws, err := workspace.Open(g.globalFlags)
+	if err!=nil {
+		return err
+	}
+	deps, err := ws.NewProcessor().ResolveDependencies(ws.Mod, ws.ModPath, target)
+	if closeErr := ws.Close(); closeErr!=nil {
+		return closeErr
+	}
+	if err!=nil {
+		return err
+	}
+
+	v := g.createView(deps)
+	switch format {
+	case FORMAT_MERMAID:
+		return writeMermaid(os.Stdout, v)
+	case FORMAT_JSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	default:
+		return writeDot(os.Stdout, v)
+	}
+}
+
+// createView filters the dependency graph by depth (and to externals if requested) and adds the toolchain nodes.
+// Nodes are ordered from the target to its deepest dependencies.
+func (g *GraphOptions) createView(deps *processor.Dependencies) *view {
+	root := deps.Graph.Root
+	depths := deps.Graph.Depths(root)
+	visible := func(n *depgraph.Node) bool {
+		depth, ok := depths[n]
+		return ok && (g.depth < 1 || depth <= g.depth)
+	}
+
+	v := &view{Root: root.Path, Nodes: []node{}, Edges: []edge{}}
+	toolchains := []string{}
+	order := slices.Clone(deps.Graph.Order())
+	slices.Reverse(order)
+	for _, n := range order {
+		if !visible(n) {
+			continue
+		}
+		if n.Type == depgraph.NODE_EXTERNAL {
+			v.Nodes = append(v.Nodes, node{ID: n.Path, Kind: "external", Module: deps.Modules[n.Path]})
+			continue
+		}
+
+		edges := []edge{}
+		for _, dep := range n.Deps {
+			if visible(dep) && (!g.externalsOnly || dep.Type == depgraph.NODE_EXTERNAL) {
+				edges = append(edges, edge{From: n.Path, To: dep.Path, Kind: "dep"})
+			}
+		}
+		if g.externalsOnly && len(edges) < 1 {
+			continue
+		}
+		v.Nodes = append(v.Nodes, node{ID: n.Path, Kind: "pack", Module: deps.Modules[n.Path]})
+		v.Edges = append(v.Edges, edges...)
+
+		if !g.externalsOnly {
+			chain := deps.Toolchains[n.Path]
+			if !slices.Contains(toolchains, chain) {
+				toolchains = append(toolchains, chain)
+			}
+			v.Edges = append(v.Edges, edge{From: n.Path, To: chain, Kind: "toolchain"})
+		}
+	}
+	for _, chain := range toolchains {
+		v.Nodes = append(v.Nodes, node{ID: chain, Kind: "toolchain"})
+	}
+	return v
+}
+
+// modules groups the nodes by module in order of their first appearance. Toolchains are not grouped.
+func (v *view) modules() ([]string, map[string][]node) {
+	order := []string{}
+	grouped := map[string][]node{}
+	for _, n := range v.Nodes {
+		if n.Module == "" {
+			continue
+		}
+		if _, ok := grouped[n.Module]; !ok {
+			order = append(order, n.Module)
+		}
+		grouped[n.Module] = append(grouped[n.Module], n)
+	}
+	return order, grouped
+}
+
+// writeDot prints the view as graphviz digraph with a cluster per module.
+func writeDot(w io.Writer, v *view) error {
+	shapes := map[string]string{"pack": "box", "external": "component", "toolchain": "ellipse"}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "digraph %s {\n", strconv.Quote(v.Root))
+	fmt.Fprintf(b, "  rankdir=LR;\n")
+	modules, grouped := v.modules()
+	for i, module := range modules {
+		fmt.Fprintf(b, "  subgraph \"cluster_%d\" {\n", i)
+		fmt.Fprintf(b, "    label=%s;\n", strconv.Quote(module))
+		for _, n := range grouped[module] {
+			fmt.Fprintf(b, "    %s [shape=%s];\n", strconv.Quote(n.ID), shapes[n.Kind])
+		}
+		fmt.Fprintf(b, "  }\n")
+	}
+	for _, n := range v.Nodes {
+		if n.Module == "" {
+			fmt.Fprintf(b, "  %s [shape=%s, style=dashed];\n", strconv.Quote(n.ID), shapes[n.Kind])
+		}
+	}
+	for _, e := range v.Edges {
+		style := ""
+		if e.Kind == "toolchain" {
+			style = " [style=dashed]"
+		}
+		fmt.Fprintf(b, "  %s -> %s%s;\n", strconv.Quote(e.From), strconv.Quote(e.To), style)
+	}
+	fmt.Fprintf(b, "}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMermaid prints the view as mermaid flowchart with a subgraph per module.
+func writeMermaid(w io.Writer, v *view) error {
+	ids := map[string]string{}
+	for i, n := range v.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+	}
+	shape := func(n node) string {
+		label := strings.ReplaceAll(n.ID, `"`, "#quot;")
+		switch n.Kind {
+		case "external":
+			return fmt.Sprintf(`%s[["%s"]]`, ids[n.ID], label)
+		case "toolchain":
+			return fmt.Sprintf(`%s(["%s"])`, ids[n.ID], label)
+		default:
+			return fmt.Sprintf(`%s["%s"]`, ids[n.ID], label)
+		}
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "graph LR\n")
+	modules, grouped := v.modules()
+	for i, module := range modules {
+		fmt.Fprintf(b, "  subgraph m%d[\"%s\"]\n", i, module)
+		for _, n := range grouped[module] {
+			fmt.Fprintf(b, "    %s\n", shape(n))
+		}
+		fmt.Fprintf(b, "  end\n")
+	}
+	for _, n := range v.Nodes {
+		if n.Module == "" {
+			fmt.Fprintf(b, "  %s\n", shape(n))
+		}
+	}
+	for _, e := range v.Edges {
+		arrow := "-->"
+		if e.Kind == "toolchain" {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(b, "  %s %s %s\n", ids[e.From], arrow, ids[e.To])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,105 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package initialize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/cmd/bob/report"
+	"github.com/megakuul/bob/internal/host"
+	modcfg "github.com/megakuul/bob/pkg/mod"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewInitCmd(options *InitOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "init <module-path>",
+		Short:        "Create a new bob module using the toolchain of the host",
+		Args:         cobra.ExactArgs(1),
+		Annotations:  map[string]string{flags.NO_MOD_ANNOTATION: ""},
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Run(args[0]); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+	options.AttachFlags(cmd.Flags())
+
+	return cmd
+}
+
+type InitOptions struct {
+	globalFlags *flags.GlobalFlags
+	force bool
+	flavor string
+}
+
+func NewInitOptions(gFlags *flags.GlobalFlags) *InitOptions {
+	return &InitOptions{
+		globalFlags: gFlags,
+	}
+}
+
+func (i *InitOptions) AttachFlags(flagSet *pflag.FlagSet) {
+	flagSet.BoolVarP(&i.force, "force", "f", false, "overwrite an existing bob module")
+	flagSet.StringVar(&i.flavor, "flavor", "", "only consider compilers of this flavor (gcc, clang)")
+}
+
+// Run writes a starter bob module to the path of the '--mod' flag (or the current directory).
+// The module declares the toolchain found on the host, so that packs can be built right away.
+func (i *InitOptions) Run(module string) error {
+	path := i.globalFlags.Mod
+	if path == "" {
+		path = modcfg.MOD_FILE_NAME
+	}
+	if _, err := os.Stat(path); err==nil && !i.force {
+		return fmt.Errorf("bob module '%s' already exists; use '--force' to overwrite it", path)
+	} else if err!=nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot access bob module '%s': %w", path, err)
+	}
+
+	chain, err := host.Probe(context.Background(), i.flavor)
+	if err!=nil {
+		return fmt.Errorf("cannot detect host toolchain: %w", err)
+	}
+
+	err = modcfg.WriteMod(path, &modcfg.Mod{
+		Module: module,
+		Toolchains: []modcfg.Toolchain{chain.Config},
+	})
+	if err!=nil {
+		return fmt.Errorf("cannot write bob mod: %w", err)
+	}
+
+	report.NewReporter(i.globalFlags.Json).Info("module initialized",
+		"module", module, "path", path, "toolchain", chain.Config.Name, "version", chain.Version,
+	)
+	return nil
+}
@@ -0,0 +1,299 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/cmd/bob/workspace"
+	"github.com/megakuul/bob/internal/mod"
+	"github.com/spf13/cobra"
+)
+
+func NewListCmd(options *ListOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the entries of the module loaded for the selected target",
+		SilenceUsage: true,
+		SilenceErrors: true,
+	}
+
+	cmd.AddCommand(
+		newEntryCmd("targets", "List targets and the toolchain they resolved to", options.Targets),
+		newEntryCmd("toolchains", "List toolchains compatible with the selected target", options.Toolchains),
+		newEntryCmd("includes", "List included modules", options.Includes),
+		newEntryCmd("externals", "List externals", options.Externals),
+	)
+
+	return cmd
+}
+
+// newEntryCmd creates the list subcommand of an entry kind.
+func newEntryCmd(use, short string, run func() error) *cobra.Command {
+	return &cobra.Command{
+		Use:          use,
+		Short:        short,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := run(); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+type ListOptions struct {
+	globalFlags *flags.GlobalFlags
+}
+
+func NewListOptions(gFlags *flags.GlobalFlags) *ListOptions {
+	return &ListOptions{
+		globalFlags: gFlags,
+	}
+}
+
+// entry is a listed entry of the module. Loaded entries are listed before skipped entries.
+type entry interface {
+	row() []string
+}
+
+// status describes if the entry was loaded, skipped as incompatible with the target or skipped as invalid.
+type status struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+var loaded = status{Status: "loaded"}
+
+func skipStatus(skip mod.Skip) status {
+	if skip.Incompatible {
+		return status{Status: "incompatible", Reason: skip.Reason.Error()}
+	}
+	return status{Status: "skipped", Reason: skip.Reason.Error()}
+}
+
+type targetEntry struct {
+	Pack string `json:"pack"`
+	Library *bool `json:"library,omitempty"`
+	Linkage string `json:"linkage,omitempty"`
+	Toolchain string `json:"toolchain,omitempty"`
+	status
+}
+
+func (t *targetEntry) row() []string {
+	// the kind of skipped targets is unknown, as only loaded targets are recorded with their configuration.
+	kind := ""
+	if t.Library != nil {
+		kind = "executable"
+		if *t.Library {
+			kind = "library"
+		}
+	}
+	return []string{t.Pack, kind, t.Linkage, t.Toolchain, t.Status, t.Reason}
+}
+
+// Targets lists all targets with the toolchain they are built with.
+func (l *ListOptions) Targets() error {
+	module, err := l.open()
+	if err!=nil {
+		return err
+	}
+
+	entries := []entry{}
+	for _, pack := range slices.Sorted(maps.Keys(module.Targets)) {
+		target := module.Targets[pack]
+		entry := &targetEntry{
+			Pack: pack,
+			Library: &target.Library,
+			Toolchain: target.Toolchain.Name,
+			status: loaded,
+		}
+		if target.Library {
+			entry.Linkage = key(mod.LINKAGES, target.Linkage)
+		}
+		entries = append(entries, entry)
+	}
+	for _, skip := range module.Skips(mod.ENTRY_TARGET) {
+		entries = append(entries, &targetEntry{Pack: skip.Name, status: skipStatus(skip)})
+	}
+	return l.print([]string{"pack", "kind", "linkage", "toolchain", "status", "reason"}, entries)
+}
+
+type toolchainEntry struct {
+	Name string `json:"name"`
+	Flavor string `json:"flavor,omitempty"`
+	Target string `json:"target,omitempty"`
+	status
+}
+
+func (t *toolchainEntry) row() []string {
+	return []string{t.Name, t.Flavor, t.Target, t.Status, t.Reason}
+}
+
+// Toolchains lists all toolchains, toolchains that do not support the selected target are listed as incompatible.
+func (l *ListOptions) Toolchains() error {
+	module, err := l.open()
+	if err!=nil {
+		return err
+	}
+
+	entries := []entry{}
+	for _, name := range slices.Sorted(maps.Keys(module.Toolchains)) {
+		chain := module.Toolchains[name]
+		entries = append(entries, &toolchainEntry{
+			Name: name,
+			Flavor: key(mod.FLAVORS, chain.Flavor),
+			Target: chain.Target,
+			status: loaded,
+		})
+	}
+	for _, skip := range module.Skips(mod.ENTRY_TOOLCHAIN) {
+		entries = append(entries, &toolchainEntry{Name: skip.Name, status: skipStatus(skip)})
+	}
+	return l.print([]string{"name", "flavor", "target", "status", "reason"}, entries)
+}
+
+type includeEntry struct {
+	Mod string `json:"mod"`
+	Source string `json:"source,omitempty"`
+	RemoteToolchain bool `json:"remote_toolchain"`
+	status
+}
+
+func (i *includeEntry) row() []string {
+	remote := ""
+	if i.RemoteToolchain {
+		remote = "yes"
+	}
+	return []string{i.Mod, i.Source, remote, i.Status, i.Reason}
+}
+
+// Includes lists all included modules with their source.
+func (l *ListOptions) Includes() error {
+	module, err := l.open()
+	if err!=nil {
+		return err
+	}
+
+	entries := []entry{}
+	for _, name := range slices.Sorted(maps.Keys(module.Includes)) {
+		include := module.Includes[name]
+		entries = append(entries, &includeEntry{
+			Mod: name,
+			Source: include.Source.URL,
+			RemoteToolchain: include.RemoteToolchain,
+			status: loaded,
+		})
+	}
+	for _, skip := range module.Skips(mod.ENTRY_INCLUDE) {
+		entries = append(entries, &includeEntry{Mod: skip.Name, status: skipStatus(skip)})
+	}
+	return l.print([]string{"mod", "source", "remote toolchain", "status", "reason"}, entries)
+}
+
+type externalEntry struct {
+	Name string `json:"name"`
+	Headers int `json:"headers"`
+	Libraries int `json:"libraries"`
+	status
+}
+
+func (e *externalEntry) row() []string {
+	return []string{e.Name, fmt.Sprint(e.Headers), fmt.Sprint(e.Libraries), e.Status, e.Reason}
+}
+
+// Externals lists all externals with the number of their header and library artifacts.
+func (l *ListOptions) Externals() error {
+	module, err := l.open()
+	if err!=nil {
+		return err
+	}
+
+	entries := []entry{}
+	for _, name := range slices.Sorted(maps.Keys(module.Externals)) {
+		external := module.Externals[name]
+		entries = append(entries, &externalEntry{
+			Name: name,
+			Headers: len(external.Headers),
+			Libraries: len(external.Libraries),
+			status: loaded,
+		})
+	}
+	for _, skip := range module.Skips(mod.ENTRY_EXTERNAL) {
+		entries = append(entries, &externalEntry{Name: skip.Name, status: skipStatus(skip)})
+	}
+	return l.print([]string{"name", "headers", "libraries", "status", "reason"}, entries)
+}
+
+// open loads the module for the selected target. No assets are loaded, so the workspace is closed immediately.
+func (l *ListOptions) open() (*mod.Mod, error) {
+	ws, err := workspace.Open(l.globalFlags)
+	if err!=nil {
+		return nil, err
+	}
+	if err:=ws.Close(); err!=nil {
+		return nil, err
+	}
+	return ws.Mod, nil
+}
+
+// print writes the entries as json array or as table with the header.
+func (l *ListOptions) print(header []string, entries []entry) error {
+	if l.globalFlags.Json {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.ToUpper(strings.Join(header, "\t")))
+	for _, entry := range entries {
+		row := entry.row()
+		for i := range row {
+			if row[i] == "" {
+				row[i] = "-"
+			}
+		}
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+// key returns the configuration name of the enum value.
+func key[T comparable](names map[string]T, value T) string {
+	for name, v := range names {
+		if v == value {
+			return name
+		}
+	}
+	return ""
+}
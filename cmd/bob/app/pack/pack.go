@@ -0,0 +1,97 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pack
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/cmd/bob/report"
+	packcfg "github.com/megakuul/bob/pkg/pack"
+	"github.com/spf13/cobra"
+)
+
+func NewPackCmd(options *PackOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "pack",
+		Short:        "Manage the packs of the bob module",
+		SilenceUsage: true,
+		SilenceErrors: true,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:          "new <dir>",
+		Short:        "Create a new pack in the directory",
+		Args:         cobra.ExactArgs(1),
+		Annotations:  map[string]string{flags.NO_MOD_ANNOTATION: ""},
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.New(args[0]); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	})
+
+	return cmd
+}
+
+type PackOptions struct {
+	globalFlags *flags.GlobalFlags
+}
+
+func NewPackOptions(gFlags *flags.GlobalFlags) *PackOptions {
+	return &PackOptions{
+		globalFlags: gFlags,
+	}
+}
+
+// New creates the directory and writes a starter bob pack into it.
+func (p *PackOptions) New(dir string) error {
+	path := filepath.Join(dir, packcfg.PACK_FILE_NAME)
+	if _, err := os.Stat(path); err==nil {
+		return fmt.Errorf("bob pack '%s' already exists", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot access bob pack '%s': %w", path, err)
+	}
+
+	if err:=os.MkdirAll(dir, 0755); err!=nil {
+		return fmt.Errorf("cannot create pack directory: %w", err)
+	}
+
+	err := packcfg.WritePack(path, &packcfg.Pack{
+		Std: packcfg.STD_C20,
+		Includes: []string{"*.h", "*.hpp"},
+		Sources: []string{"*.cpp", "*.cc"},
+		Tests: []string{"*_test.cpp"},
+	})
+	if err!=nil {
+		return fmt.Errorf("cannot write bob pack: %w", err)
+	}
+
+	report.NewReporter(p.globalFlags.Json).Info("pack created", "path", path)
+	return nil
+}
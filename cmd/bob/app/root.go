@@ -31,7 +31,17 @@ import (
 	"github.com/lmittmann/tint"
 	modcfg "github.com/megakuul/bob/pkg/mod"
 
+	"github.com/megakuul/bob/cmd/bob/app/build"
+	"github.com/megakuul/bob/cmd/bob/app/compdb"
+	"github.com/megakuul/bob/cmd/bob/app/graph"
+	"github.com/megakuul/bob/cmd/bob/app/initialize"
+	"github.com/megakuul/bob/cmd/bob/app/list"
+	"github.com/megakuul/bob/cmd/bob/app/pack"
 	"github.com/megakuul/bob/cmd/bob/app/run"
+	"github.com/megakuul/bob/cmd/bob/app/sum"
+	"github.com/megakuul/bob/cmd/bob/app/test"
+	"github.com/megakuul/bob/cmd/bob/app/toolchain"
+	"github.com/megakuul/bob/cmd/bob/app/why"
 	"github.com/megakuul/bob/cmd/bob/flags"
 	"github.com/spf13/cobra"
 )
@@ -49,6 +59,16 @@ func NewRootCmd() *cobra.Command {
 
 	cmd.AddCommand(
 		run.NewRunCmd(run.NewRunOptions(options.globalFlags)),
+		build.NewBuildCmd(build.NewBuildOptions(options.globalFlags)),
+		test.NewTestCmd(test.NewTestOptions(options.globalFlags)),
+		sum.NewSumCmd(sum.NewSumOptions(options.globalFlags)),
+		initialize.NewInitCmd(initialize.NewInitOptions(options.globalFlags)),
+		pack.NewPackCmd(pack.NewPackOptions(options.globalFlags)),
+		toolchain.NewToolchainCmd(toolchain.NewToolchainOptions(options.globalFlags)),
+		compdb.NewCompdbCmd(compdb.NewCompdbOptions(options.globalFlags)),
+		graph.NewGraphCmd(graph.NewGraphOptions(options.globalFlags)),
+		why.NewWhyCmd(why.NewWhyOptions(options.globalFlags)),
+		list.NewListCmd(list.NewListOptions(options.globalFlags)),
 	)
 
 	return cmd
@@ -66,6 +86,9 @@ func NewRootOptions(gFlags *flags.GlobalFlags) *RootOptions {
 
 func (r *RootOptions) PreRun(cmd *cobra.Command, args []string) error {
 	slog.SetDefault(obtainLogger(r.globalFlags.Verbose, r.globalFlags.Traces, r.globalFlags.Json))
+	if _, ok := cmd.Annotations[flags.NO_MOD_ANNOTATION]; ok {
+		return nil
+	}
 
 	path, err := obtainMod(r.globalFlags.Mod)
 	if err!=nil {
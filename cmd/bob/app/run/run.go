@@ -20,26 +20,33 @@
 package run
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
-	"path/filepath"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
 
+	"github.com/megakuul/bob/cmd/bob/app/build"
 	"github.com/megakuul/bob/cmd/bob/flags"
-	"github.com/megakuul/bob/internal/mod"
 	"github.com/megakuul/bob/internal/processor"
-	modcfg "github.com/megakuul/bob/pkg/mod"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
 func NewRunCmd(options *RunOptions) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "run",
+		Use:          "run <target> [-- args...]",
+		Short:        "Build a target and execute it",
 		SilenceUsage: true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := options.Run(args); err!=nil {
-				slog.Error(err.Error())
+			if err := options.Run(args, cmd.ArgsLenAtDash()); err!=nil {
+				// the exit code of the target is forwarded, the target reported its failure itself.
+				if !errors.As(err, new(*ExitError)) {
+					slog.Error(err.Error())
+				}
 				return err
 			}
 			return nil
@@ -50,56 +57,107 @@ func NewRunCmd(options *RunOptions) *cobra.Command {
 	return cmd
 }
 
+// ExitError reports that the executed target exited with a non-zero exit code.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("target exited with code '%d'", e.Code)
+}
+
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
 type RunOptions struct {
 	globalFlags *flags.GlobalFlags
-	output string
-	clean bool
+	build *build.BuildOptions
 }
 
 func NewRunOptions(gFlags *flags.GlobalFlags) *RunOptions {
 	return &RunOptions{
 		globalFlags: gFlags,
+		build: build.NewBuildOptions(gFlags),
 	}
 }
 
 func (r *RunOptions) AttachFlags(flagSet *pflag.FlagSet) {
-	flagSet.BoolVarP(&r.clean, "clean", "c", false, "cleanup cache before execution") 
+	r.build.AttachFlags(flagSet)
 }
 
-func (r *RunOptions) Run(args []string) error {
-	if len(args) != 1 {
-		return fmt.Errorf("expected exactly '%d' argument got '%d'", 1, len(args))
-	}
-	target := args[0]
-	
-	modCfg, err := modcfg.LoadMod(r.globalFlags.Mod)
-	if err!=nil {
-		return fmt.Errorf("cannot read bob mod: %w", err)
-	}
-
-	modPlatform, ok := mod.PLATFORMS[r.globalFlags.Platform]
-	if !ok {
-		return fmt.Errorf("unknown platform '%s'; use one of '%v'", r.globalFlags.Platform, mod.PLATFORMS)
+// Run builds the target and executes it. Arguments after the dash ('--') are passed to the target.
+func (r *RunOptions) Run(args []string, dashIndex int) error {
+	if dashIndex < 0 {
+		dashIndex = len(args)
 	}
-
-	modArch, ok := mod.ARCHS[r.globalFlags.Arch]
-	if !ok {
-		return fmt.Errorf("unknown architecture '%s'; use one of '%v'", r.globalFlags.Arch, mod.ARCHS)
+	if dashIndex != 1 {
+		return fmt.Errorf("expected exactly '%d' argument before '--' got '%d'", 1, dashIndex)
 	}
 
-	mod, err := mod.LoadMod(modCfg, modPlatform, modArch)
+	output, err := r.build.Build(args[0])
 	if err!=nil {
-		return fmt.Errorf("cannot load bob mod: %w", err)
+		return err
+	}
+	if output.Library {
+		return fmt.Errorf("target '%s' is a library and cannot be executed", args[0])
 	}
 
-	proc := processor.NewProcessor()
+	return execute(output, args[dashIndex:])
+}
 
-	err = proc.BuildTarget(mod, target, filepath.Dir(r.globalFlags.Mod))
-	if err!=nil {
-		return err
+// execute runs the executable with the standard streams of bob attached. Signals aimed at bob (SIGTERM, SIGHUP)
+// are forwarded to the executable. Terminal signals (SIGINT, SIGQUIT) are sent to the whole foreground process
+// group by the terminal and therefore already reach the executable, bob only catches them to keep waiting for
+// it. A non-zero exit code is returned as ExitError. The rpaths of the externals are added to the
+// library search path so that dynamically linked externals are resolved.
+func execute(output *processor.Output, args []string) error {
+	cmd := exec.Command(output.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = output.Environ()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(signals)
+	// the signals are caught instead of ignored, as ignored signals would be inherited by the executable.
+	terminalSignals := make(chan os.Signal, 1)
+	signal.Notify(terminalSignals, os.Interrupt, syscall.SIGQUIT)
+	defer signal.Stop(terminalSignals)
+
+	if err:=cmd.Start(); err!=nil {
+		return fmt.Errorf("cannot execute target: %w", err)
 	}
 
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if err:=cmd.Process.Signal(sig); err!=nil {
+					slog.Debug(fmt.Sprintf("cannot forward signal '%s' to target: %v", sig, err))
+				}
+			case sig := <-terminalSignals:
+				slog.Debug(fmt.Sprintf("received terminal signal '%s'; waiting for target...", sig))
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := cmd.Wait()
+	exitErr := &exec.ExitError{}
+	if errors.As(err, &exitErr) {
+		code := exitErr.ExitCode()
+		// processes terminated by a signal report -1, the shell convention 128+signal is used instead.
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			code = 128 + int(status.Signal())
+		}
+		return &ExitError{Code: code}
+	} else if err!=nil {
+		return fmt.Errorf("cannot execute target: %w", err)
+	}
 	return nil
 }
-
-
@@ -0,0 +1,341 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/internal/host"
+	"github.com/megakuul/bob/internal/loader"
+	"github.com/megakuul/bob/internal/mvs"
+	modcfg "github.com/megakuul/bob/pkg/mod"
+	sumcfg "github.com/megakuul/bob/pkg/sum"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+func NewSumCmd(options *SumOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "sum",
+		Short:        "Maintain the checksum file of the bob module",
+		SilenceUsage: true,
+		SilenceErrors: true,
+	}
+
+	cmd.AddCommand(
+		newSumModeCmd("verify", "Verify all checksums against the fetched artifacts", options.Verify),
+		newSumModeCmd("update", "Refetch all referenced artifacts and recompute their checksums", options.Update),
+		newSumModeCmd("tidy", "Remove checksums of artifacts no longer referenced by the module", options.Tidy),
+	)
+
+	return cmd
+}
+
+func newSumModeCmd(use, short string, run func() error) *cobra.Command {
+	return &cobra.Command{
+		Use:          use,
+		Short:        short,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := run(); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+type SumOptions struct {
+	globalFlags *flags.GlobalFlags
+}
+
+func NewSumOptions(gFlags *flags.GlobalFlags) *SumOptions {
+	return &SumOptions{
+		globalFlags: gFlags,
+	}
+}
+
+// Verify fetches every artifact listed in the checksum file and compares its content hash with the entry.
+// Artifacts referenced by the module without entry are reported as well, as the build would pin them unverified.
+// Entries of local directories are not verified, as they are not pinned.
+func (s *SumOptions) Verify() error {
+	sumPath, sumCfg, err := s.loadSum()
+	if err!=nil {
+		return err
+	}
+	modCfg, err := modcfg.LoadMod(s.globalFlags.Mod)
+	if err!=nil {
+		return fmt.Errorf("cannot read bob mod: %w", err)
+	}
+
+	referenced, err := s.referencedURLs(modCfg)
+	if err!=nil {
+		return err
+	}
+	mismatches := []error{}
+	for _, url := range referenced {
+		if _, ok := sumCfg.Paths[url]; !ok {
+			mismatches = append(mismatches, fmt.Errorf("missing checksum for '%s'", url))
+		}
+	}
+
+	urls := []string{}
+	for url := range sumCfg.Paths {
+		if !loader.Verifiable(url) {
+			slog.Debug(fmt.Sprintf("'%s' is a local directory; skipping verification...", url))
+			continue
+		}
+		urls = append(urls, url)
+	}
+
+	mismatchLock := sync.Mutex{}
+	err = s.hashAll(false, urls, func(url, hash string) {
+		if hash != sumCfg.Paths[url] {
+			mismatchLock.Lock()
+			mismatches = append(mismatches, fmt.Errorf(
+				"checksum mismatch for '%s': expected '%s' got '%s'", url, sumCfg.Paths[url], hash,
+			))
+			mismatchLock.Unlock()
+		}
+	})
+	if err!=nil {
+		return err
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("'%s' is not valid:\n%w", filepath.Base(sumPath), errors.Join(mismatches...))
+	}
+	return nil
+}
+
+// Update refetches every artifact referenced by the module and overwrites its entry with the new content hash.
+func (s *SumOptions) Update() error {
+	sumPath, sumCfg, err := s.loadSum()
+	if err!=nil {
+		return err
+	}
+	modCfg, err := modcfg.LoadMod(s.globalFlags.Mod)
+	if err!=nil {
+		return fmt.Errorf("cannot read bob mod: %w", err)
+	}
+
+	referenced, err := s.referencedURLs(modCfg)
+	if err!=nil {
+		return err
+	}
+
+	hashLock := sync.Mutex{}
+	err = s.hashAll(true, referenced, func(url, hash string) {
+		hashLock.Lock()
+		sumCfg.Paths[url] = hash
+		hashLock.Unlock()
+	})
+	if err!=nil {
+		return err
+	}
+	return sumcfg.WriteSum(sumPath, sumCfg)
+}
+
+// Tidy removes all entries of the checksum file that are not referenced by the module.
+func (s *SumOptions) Tidy() error {
+	sumPath, sumCfg, err := s.loadSum()
+	if err!=nil {
+		return err
+	}
+	modCfg, err := modcfg.LoadMod(s.globalFlags.Mod)
+	if err!=nil {
+		return fmt.Errorf("cannot read bob mod: %w", err)
+	}
+
+	referenced, err := s.referencedURLs(modCfg)
+	if err!=nil {
+		return err
+	}
+	for url := range sumCfg.Paths {
+		if !slices.Contains(referenced, url) {
+			slog.Debug(fmt.Sprintf("removing unreferenced checksum of '%s'...", url))
+			delete(sumCfg.Paths, url)
+		}
+	}
+	return sumcfg.WriteSum(sumPath, sumCfg)
+}
+
+// loadSum reads the checksum file of the module. A missing checksum file results in an empty one.
+func (s *SumOptions) loadSum() (string, *sumcfg.Sum, error) {
+	sumPath := filepath.Join(filepath.Dir(s.globalFlags.Mod), sumcfg.SUM_FILE_NAME)
+	sumCfg, err := sumcfg.LoadSum(sumPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return sumPath, sumcfg.NewSum(), nil
+	} else if err!=nil {
+		return "", nil, fmt.Errorf("cannot read bob sum: %w", err)
+	}
+	return sumPath, sumCfg, nil
+}
+
+// hashAll fetches all artifacts in parallel and reports their content hash to the handler.
+// The loader runs without checksum database as the checksums are evaluated by the handler.
+func (s *SumOptions) hashAll(clean bool, urls []string, handler func(url, hash string)) error {
+	load := s.newLoader()
+
+	group, _ := errgroup.WithContext(context.Background())
+	for _, url := range urls {
+		group.Go(func() error {
+			typ, err := loader.ParseType(url)
+			if err!=nil {
+				return err
+			}
+			path, err := load.Load(typ, url, clean)
+			if err!=nil {
+				return fmt.Errorf("failed to load '%s': %w", url, err)
+			}
+			hash, err := loader.HashDir(path)
+			if err!=nil {
+				return fmt.Errorf("failed to hash '%s': %w", url, err)
+			}
+			handler(url, hash)
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// newLoader creates a loader for the module cache. The loader runs without checksum database.
+func (s *SumOptions) newLoader() *loader.Loader {
+	cachePath := filepath.Join(filepath.Dir(s.globalFlags.Mod), ".bobcache")
+	return loader.NewLoader(context.Background(), loader.WithRootPath(cachePath))
+}
+
+// referencedURLs collects the urls of all artifacts referenced by the module and its transitive includes
+// regardless of platform or arch. Local directories are not pinned and therefore not collected.
+// Like the build, the sources of every required include revision are fetched, but the artifacts are only
+// collected from the revisions selected with the minimal version selection.
+func (s *SumOptions) referencedURLs(cfg *modcfg.Mod) ([]string, error) {
+	load := s.newLoader()
+
+	urls := []string{}
+	add := func(added []string) {
+		for _, url := range added {
+			if loader.Verifiable(url) && !slices.Contains(urls, url) {
+				urls = append(urls, url)
+			}
+		}
+	}
+
+	type revisionCfg struct {
+		id string
+		cfg *modcfg.Mod
+	}
+	modules, _, err := mvs.Resolve(&revisionCfg{id: cfg.Module, cfg: cfg}, cfg.Module,
+		func(current *revisionCfg) string { return current.id },
+		func(current *revisionCfg) []mvs.Include {
+			includes := []mvs.Include{}
+			for _, include := range current.cfg.Includes {
+				base, revision := loader.SplitRevision(include.Source.URL)
+				includes = append(includes, mvs.Include{
+					Module: include.Mod, Source: include.Source.URL, Base: base, Revision: revision,
+				})
+			}
+			return includes
+		},
+		func(parent *revisionCfg, include mvs.Include) (*revisionCfg, error) {
+			index := slices.IndexFunc(parent.cfg.Includes, func(other modcfg.Include) bool {
+				return other.Mod == include.Module
+			})
+			source := parent.cfg.Includes[index].Source
+			add([]string{source.URL})
+
+			typ, err := loader.ParseType(source.URL)
+			if err!=nil {
+				return nil, err
+			}
+			dir, err := load.Load(typ, source.URL, false)
+			if err!=nil {
+				return nil, err
+			}
+			includeCfg, err := modcfg.LoadMod(filepath.Join(dir, source.Path, modcfg.MOD_FILE_NAME))
+			if err!=nil {
+				return nil, fmt.Errorf("cannot read bob mod: %w", err)
+			}
+
+			id := include.Module
+			if include.Revision != "" {
+				id = fmt.Sprintf("%s@%s", include.Module, include.Revision)
+			}
+			return &revisionCfg{id: id, cfg: includeCfg}, nil
+		},
+	)
+	if err!=nil {
+		return nil, err
+	}
+
+	add(artifactURLs(cfg))
+	for _, modulePath := range slices.Sorted(maps.Keys(modules)) {
+		if modulePath != cfg.Module {
+			add(artifactURLs(modules[modulePath].cfg))
+		}
+	}
+	return urls, nil
+}
+
+// artifactURLs collects the urls of all toolchain and external artifacts directly referenced by the module.
+func artifactURLs(cfg *modcfg.Mod) []string {
+	paths := []modcfg.Path{}
+	for _, chain := range cfg.Toolchains {
+		if chain.Auto {
+			detected, err := host.Synthesize(chain)
+			if err!=nil {
+				slog.Warn(fmt.Sprintf("cannot detect host toolchain: %v; skipping toolchain '%s'...", err, chain.Name))
+				continue
+			}
+			chain = *detected
+		}
+		if chain.Sysroot != nil {
+			paths = append(paths, *chain.Sysroot)
+		}
+		paths = append(paths, chain.Compiler, chain.Linker, chain.Interpreter, chain.Archiver)
+		paths = append(paths, chain.Stdlib, chain.Stdpplib)
+		paths = append(paths, chain.Supportlibs...)
+		paths = append(paths, chain.Startfiles...)
+		paths = append(paths, chain.SharedStartfiles...)
+	}
+	for _, external := range cfg.Externals {
+		paths = append(paths, external.Headers...)
+		paths = append(paths, external.Libraries...)
+	}
+
+	urls := []string{}
+	for _, path := range paths {
+		if path.URL != "" && !slices.Contains(urls, path.URL) {
+			urls = append(urls, path.URL)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,249 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/cmd/bob/report"
+	"github.com/megakuul/bob/cmd/bob/workspace"
+	"github.com/megakuul/bob/internal/mod"
+	"github.com/megakuul/bob/internal/processor"
+	"github.com/megakuul/bob/pkg/pack"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
+)
+
+func NewTestCmd(options *TestOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "test [pattern]",
+		Short:        "Build and run the tests of all packs matching the pattern",
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Run(args); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+	options.AttachFlags(cmd.Flags())
+
+	return cmd
+}
+
+type TestOptions struct {
+	globalFlags *flags.GlobalFlags
+	toolchain string
+	clean bool
+	jobs int
+	profile string
+}
+
+func NewTestOptions(gFlags *flags.GlobalFlags) *TestOptions {
+	return &TestOptions{
+		globalFlags: gFlags,
+	}
+}
+
+func (t *TestOptions) AttachFlags(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&t.toolchain, "toolchain", "", "toolchain used for packs that are no target")
+	flagSet.BoolVarP(&t.clean, "clean", "c", false, "cleanup cache before execution")
+	flagSet.IntVar(&t.jobs, "jobs", runtime.NumCPU(), "maximum number of parallel compile, link and test jobs")
+	flagSet.StringVar(&t.profile, "profile", "", "profile (e.g. debug, asan) whose flags are used for the tests")
+}
+
+// Run discovers all packs of the module with tests matching the pattern (a regular expression applied to the
+// pack path), builds their test executables and runs them in parallel.
+func (t *TestOptions) Run(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("expected at most '%d' argument got '%d'", 1, len(args))
+	}
+	if t.jobs < 1 {
+		return fmt.Errorf("expected at least '%d' job got '%d'", 1, t.jobs)
+	}
+	pattern := regexp.MustCompile("")
+	if len(args) == 1 {
+		var err error
+		pattern, err = regexp.Compile(args[0])
+		if err!=nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+
+	ws, err := workspace.Open(t.globalFlags)
+	if err!=nil {
+		return err
+	}
+
+	packs, err := discoverTestPacks(ws.Mod, ws.ModPath)
+	if err!=nil {
+		return err
+	}
+
+	profile, err := ws.Mod.Profile(t.profile)
+	if err!=nil {
+		return err
+	}
+
+	proc := ws.NewProcessor(processor.WithClean(t.clean), processor.WithJobs(t.jobs), processor.WithProfile(profile))
+	tests := []*processor.Output{}
+	for _, packPath := range packs {
+		if !pattern.MatchString(packPath) {
+			continue
+		}
+		chain, err := t.selectToolchain(ws.Mod, packPath)
+		if err!=nil {
+			return err
+		}
+		outputs, err := proc.BuildTests(ws.Mod, ws.ModPath, packPath, chain)
+		if err!=nil {
+			ws.Close()
+			return err
+		}
+		tests = append(tests, outputs...)
+	}
+	if err:=ws.Close(); err!=nil {
+		return err
+	}
+
+	return t.runTests(proc.TestPath(), tests)
+}
+
+// runTests executes all tests in parallel and reports the result of every test.
+func (t *TestOptions) runTests(testPath string, tests []*processor.Output) error {
+	reporter := report.NewReporter(t.globalFlags.Json)
+
+	failed := atomic.Int64{}
+	group := errgroup.Group{}
+	group.SetLimit(t.jobs)
+	for _, test := range tests {
+		group.Go(func() error {
+			name, err := filepath.Rel(testPath, test.Path)
+			if err!=nil {
+				return err
+			}
+			name = filepath.ToSlash(name)
+
+			output := bytes.Buffer{}
+			cmd := exec.CommandContext(context.Background(), test.Path)
+			cmd.Env = test.Environ()
+			cmd.Dir = filepath.Dir(test.Path)
+			cmd.Stdout = &output
+			cmd.Stderr = &output
+
+			start := time.Now()
+			err = cmd.Run()
+			duration := time.Since(start)
+			if err!=nil {
+				failed.Add(1)
+				reporter.Error("test failed",
+					"test", name, "duration", duration, "error", err, "output", strings.TrimSpace(output.String()),
+				)
+				return nil
+			}
+			reporter.Info("test passed", "test", name, "duration", duration)
+			return nil
+		})
+	}
+	if err:=group.Wait(); err!=nil {
+		return err
+	}
+
+	if failed.Load() > 0 {
+		return fmt.Errorf("'%d' of '%d' tests failed", failed.Load(), len(tests))
+	}
+	return nil
+}
+
+// selectToolchain selects the toolchain for the tests of a pack. An explicitly selected toolchain is preferred,
+// otherwise the toolchain of the target of the pack or the only available toolchain is used.
+func (t *TestOptions) selectToolchain(module *mod.Mod, packPath string) (*mod.Toolchain, error) {
+	if t.toolchain != "" {
+		chain, ok := module.Toolchains[t.toolchain]
+		if !ok {
+			return nil, fmt.Errorf("toolchain '%s' is not available", t.toolchain)
+		}
+		return &chain, nil
+	}
+	if target, ok := module.Targets[packPath]; ok {
+		return target.Toolchain, nil
+	}
+	if len(module.Toolchains) == 1 {
+		for _, chain := range module.Toolchains {
+			return &chain, nil
+		}
+	}
+	return nil, fmt.Errorf("pack '%s' is no target; select a toolchain with '--toolchain'", packPath)
+}
+
+// discoverTestPacks walks the module directory and returns the paths of all packs that declare tests.
+// Hidden directories (e.g. '.bobcache' or '.git') are skipped.
+func discoverTestPacks(module *mod.Mod, modPath string) ([]string, error) {
+	packs := []string{}
+	err := filepath.WalkDir(modPath, func(path string, entry fs.DirEntry, err error) error {
+		if err!=nil {
+			return err
+		}
+		if entry.IsDir() && path != modPath && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if entry.IsDir() || entry.Name() != pack.PACK_FILE_NAME {
+			return nil
+		}
+
+		packCfg, err := pack.LoadPack(path)
+		if err!=nil {
+			return fmt.Errorf("cannot read bob pack '%s': %w", path, err)
+		}
+		if len(packCfg.Tests) < 1 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(modPath, filepath.Dir(path))
+		if err!=nil {
+			return err
+		}
+		if rel == "." {
+			packs = append(packs, module.Module)
+		} else {
+			packs = append(packs, module.Module+"/"+filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err!=nil {
+		return nil, fmt.Errorf("failed to discover packs: %w", err)
+	}
+	return packs, nil
+}
@@ -0,0 +1,145 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package toolchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/internal/host"
+	modcfg "github.com/megakuul/bob/pkg/mod"
+	"github.com/spf13/cobra"
+)
+
+func NewToolchainCmd(options *ToolchainOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "toolchain",
+		Short:        "Inspect the toolchains available to bob",
+		SilenceUsage: true,
+		SilenceErrors: true,
+	}
+
+	showCmd := &cobra.Command{
+		Use:          "show",
+		Short:        "Show the toolchain detected on the host",
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{flags.NO_MOD_ANNOTATION: ""},
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Show(); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+	showCmd.Flags().StringVar(&options.flavor, "flavor", "", "only consider compilers of this flavor (gcc, clang)")
+	cmd.AddCommand(showCmd)
+
+	return cmd
+}
+
+type ToolchainOptions struct {
+	globalFlags *flags.GlobalFlags
+	flavor string
+}
+
+func NewToolchainOptions(gFlags *flags.GlobalFlags) *ToolchainOptions {
+	return &ToolchainOptions{
+		globalFlags: gFlags,
+	}
+}
+
+type artifact struct {
+	Kind string `json:"kind"`
+	Path string `json:"path"`
+}
+
+type hostToolchain struct {
+	Flavor string `json:"flavor"`
+	Version string `json:"version"`
+	Triple string `json:"triple"`
+	Artifacts []artifact `json:"artifacts"`
+}
+
+// Show probes the host and prints the toolchain that is synthesized for toolchains with 'auto = true'.
+func (t *ToolchainOptions) Show() error {
+	detected, err := host.Probe(context.Background(), t.flavor)
+	if err!=nil {
+		return fmt.Errorf("cannot detect host toolchain: %w", err)
+	}
+
+	chain := &hostToolchain{
+		Flavor: detected.Flavor,
+		Version: detected.Version,
+		Triple: detected.Triple,
+		Artifacts: artifacts(&detected.Config),
+	}
+
+	if t.globalFlags.Json {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(chain)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "flavor\t%s\n", chain.Flavor)
+	fmt.Fprintf(writer, "version\t%s\n", chain.Version)
+	fmt.Fprintf(writer, "triple\t%s\n", chain.Triple)
+	for _, artifact := range chain.Artifacts {
+		fmt.Fprintf(writer, "%s\t%s\n", artifact.Kind, artifact.Path)
+	}
+	return writer.Flush()
+}
+
+// artifacts lists the local paths of all artifacts of the toolchain.
+func artifacts(chain *modcfg.Toolchain) []artifact {
+	paths := []artifact{
+		{Kind: "compiler", Path: localPath(chain.Compiler)},
+		{Kind: "linker", Path: localPath(chain.Linker)},
+		{Kind: "interpreter", Path: localPath(chain.Interpreter)},
+		{Kind: "archiver", Path: localPath(chain.Archiver)},
+		{Kind: "stdlib", Path: localPath(chain.Stdlib)},
+		{Kind: "stdpplib", Path: localPath(chain.Stdpplib)},
+	}
+	for _, lib := range chain.Supportlibs {
+		paths = append(paths, artifact{Kind: "supportlib", Path: localPath(lib)})
+	}
+	for _, file := range chain.Startfiles {
+		paths = append(paths, artifact{Kind: "startfile", Path: localPath(file)})
+	}
+	for _, file := range chain.SharedStartfiles {
+		paths = append(paths, artifact{Kind: "shared_startfile", Path: localPath(file)})
+	}
+	return paths
+}
+
+// localPath converts a local artifact into its path on the host.
+func localPath(path modcfg.Path) string {
+	return filepath.Join(strings.TrimPrefix(path.URL, "file://"), path.Path)
+}
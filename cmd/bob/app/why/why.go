@@ -0,0 +1,154 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package why
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/cmd/bob/workspace"
+	"github.com/megakuul/bob/internal/graph"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewWhyCmd(options *WhyOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "why <dep> [target...]",
+		Short:        "Show why a pack or external is part of the build of a target",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Run(args[0], args[1:]); err!=nil {
+				slog.Error(err.Error())
+				return err
+			}
+			return nil
+		},
+	}
+	options.AttachFlags(cmd.Flags())
+
+	return cmd
+}
+
+type WhyOptions struct {
+	globalFlags *flags.GlobalFlags
+}
+
+func NewWhyOptions(gFlags *flags.GlobalFlags) *WhyOptions {
+	return &WhyOptions{
+		globalFlags: gFlags,
+	}
+}
+
+func (w *WhyOptions) AttachFlags(flagSet *pflag.FlagSet) {}
+
+// Explanation contains every shortest path from the target to a node matching the dependency.
+type Explanation struct {
+	Target string `json:"target"`
+	Dep string `json:"dep"`
+	Paths [][]string `json:"paths"`
+}
+
+// Run explains the dependency for all specified targets (or all targets of the module if none is specified).
+// The dependency is either a full import path or the name of an external (e.g. 'z'), which matches the
+// external in every module.
+func (w *WhyOptions) Run(dep string, targets []string) error {
+	ws, err := workspace.Open(w.globalFlags)
+	if err!=nil {
+		return err
+	}
+	if len(targets) < 1 {
+		targets = slices.Sorted(maps.Keys(ws.Mod.Targets))
+	}
+
+	proc := ws.NewProcessor()
+	explanations := []*Explanation{}
+	for _, target := range targets {
+		deps, err := proc.ResolveDependencies(ws.Mod, ws.ModPath, target)
+		if err!=nil {
+			ws.Close()
+			return fmt.Errorf("failed to resolve dependencies of target '%s': %w", target, err)
+		}
+		for _, node := range deps.Graph.Order() {
+			if !matches(node, dep) {
+				continue
+			}
+			explanation := &Explanation{Target: target, Dep: node.Path, Paths: [][]string{}}
+			for _, path := range deps.Graph.ShortestPaths(deps.Graph.Root, node) {
+				explanation.Paths = append(explanation.Paths, pathStrings(path))
+			}
+			explanations = append(explanations, explanation)
+		}
+	}
+	if err:=ws.Close(); err!=nil {
+		return err
+	}
+
+	if w.globalFlags.Json {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(explanations)
+	}
+	if len(explanations) < 1 {
+		fmt.Printf("(no target depends on '%s')\n", dep)
+		return nil
+	}
+	b := &strings.Builder{}
+	for i, explanation := range explanations {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "# %s (%s)\n", explanation.Dep, explanation.Target)
+		for j, path := range explanation.Paths {
+			if j > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(strings.Join(path, "\n") + "\n")
+		}
+	}
+	fmt.Print(b.String())
+	return nil
+}
+
+// matches checks if the node is the dependency, either by import path or by external name.
+func matches(node *graph.Node, dep string) bool {
+	if node.Path == dep {
+		return true
+	}
+	_, name, ok := graph.ParseExternal(node.Path)
+	return ok && (name == dep || graph.EXTERNAL_PREFIX+name == dep)
+}
+
+// pathStrings converts the path nodes to their import paths.
+func pathStrings(path []*graph.Node) []string {
+	paths := make([]string, len(path))
+	for i, node := range path {
+		paths[i] = node.Path
+	}
+	return paths
+}
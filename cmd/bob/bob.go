@@ -20,6 +20,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/megakuul/bob/cmd/bob/app"
@@ -28,6 +29,11 @@ import (
 func main() {
 	cmd := app.NewRootCmd()
 	if err :=  cmd.Execute(); err!=nil {
+		// commands executing other programs forward their exit code.
+		var exitErr interface{ ExitCode() int }
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
 		os.Exit(1)
 	}
 	os.Exit(0)
@@ -25,6 +25,9 @@ import (
 	"github.com/spf13/pflag"
 )
 
+// NO_MOD_ANNOTATION marks commands that operate without an existing bob module.
+const NO_MOD_ANNOTATION = "bob/no-mod"
+
 type GlobalFlags struct {
 	Verbose bool
 	Traces bool
@@ -32,6 +35,8 @@ type GlobalFlags struct {
 	Mod string
 	Platform string
 	Arch string
+	Target string
+	Strict bool
 }
 
 func NewGlobalFlags() *GlobalFlags {
@@ -45,4 +50,6 @@ func (g *GlobalFlags) AttachFlags(flags *pflag.FlagSet) {
 	flags.StringVarP(&g.Mod, "mod", "m", "", "Specifies the path of the bob module")
 	flags.StringVarP(&g.Platform, "platform", "p", runtime.GOOS, "Specifies the target platform")
 	flags.StringVarP(&g.Arch, "arch", "a", runtime.GOARCH, "Specifies the target cpu arch")
+	flags.StringVar(&g.Target, "target", "", "Specifies the target triple (e.g. aarch64-linux-gnu); overrides platform and arch")
+	flags.BoolVar(&g.Strict, "strict", false, "Fail if module entries are invalid instead of skipping them")
 }
@@ -0,0 +1,57 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package report
+
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/lmittmann/tint"
+)
+
+// NewReporter creates a logger for command results. Results are reported regardless of the log level
+// in the same format as the logs.
+func NewReporter(json bool) *slog.Logger {
+	if json {
+		return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}))
+	}
+	return slog.New(tint.NewHandler(os.Stdout, &tint.Options{
+		Level: slog.LevelInfo,
+		TimeFormat: time.Kitchen,
+	}))
+}
+
+// NewNotifier creates a logger for notes about the command input (e.g. skipped entries or selected revisions).
+// Notes are reported regardless of the log level and are written to stderr, so that they do not interfere
+// with the command results.
+func NewNotifier(json bool) *slog.Logger {
+	if json {
+		return slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}))
+	}
+	return slog.New(tint.NewHandler(os.Stderr, &tint.Options{
+		Level: slog.LevelInfo,
+		TimeFormat: time.Kitchen,
+	}))
+}
@@ -0,0 +1,139 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/megakuul/bob/cmd/bob/flags"
+	"github.com/megakuul/bob/cmd/bob/report"
+	"github.com/megakuul/bob/internal/loader"
+	"github.com/megakuul/bob/internal/mod"
+	"github.com/megakuul/bob/internal/processor"
+	modcfg "github.com/megakuul/bob/pkg/mod"
+	"github.com/megakuul/bob/pkg/sum"
+)
+
+// Workspace contains the loaded bob module of a command together with the loader of its cache.
+type Workspace struct {
+	Mod *mod.Mod
+	ModPath string
+	CachePath string
+
+	ctx context.Context
+	json bool
+	sumPath string
+	loader *loader.Loader
+}
+
+// Open reads the bob module specified by the global flags and creates it for the selected platform / arch.
+// All assets loaded through the workspace are verified against the checksum file of the module.
+func Open(gFlags *flags.GlobalFlags) (*Workspace, error) {
+	modCfg, err := modcfg.LoadMod(gFlags.Mod)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot read bob mod '%s': %w", gFlags.Mod, err)
+	}
+
+	target, err := selectTarget(gFlags)
+	if err!=nil {
+		return nil, err
+	}
+
+	module, err := mod.CreateMod(modCfg, *target, gFlags.Strict)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot load bob mod: %w", err)
+	}
+	reportSkipped(module, gFlags.Json)
+
+	ctx := context.Background()
+	modPath := filepath.Dir(gFlags.Mod)
+	cachePath := filepath.Join(modPath, ".bobcache")
+
+	sumPath := filepath.Join(modPath, sum.SUM_FILE_NAME)
+	sumCfg, err := sum.LoadSum(sumPath)
+	if errors.Is(err, os.ErrNotExist) {
+		sumCfg = sum.NewSum()
+	} else if err!=nil {
+		return nil, fmt.Errorf("cannot read bob sum: %w", err)
+	}
+
+	return &Workspace{
+		Mod: module,
+		ModPath: modPath,
+		CachePath: cachePath,
+		ctx: ctx,
+		json: gFlags.Json,
+		sumPath: sumPath,
+		loader: loader.NewLoader(ctx, loader.WithRootPath(cachePath), loader.WithSum(sumCfg)),
+	}, nil
+}
+
+// NewProcessor creates a processor operating on the cache of the workspace.
+func (w *Workspace) NewProcessor(opts ...processor.ProcessorOption) *processor.Processor {
+	return processor.NewProcessor(append([]processor.ProcessorOption{
+		processor.WithContext(w.ctx),
+		processor.WithLoader(w.loader),
+		processor.WithCachePath(w.CachePath),
+		processor.WithReporter(report.NewNotifier(w.json)),
+	}, opts...)...)
+}
+
+// Close persists checksums added while loading assets. It must also be called if the operation failed,
+// as the added checksums are verified regardless of the operation.
+func (w *Workspace) Close() error {
+	if sumCfg, modified := w.loader.Sum(); modified {
+		if err:=sum.WriteSum(w.sumPath, sumCfg); err!=nil {
+			return fmt.Errorf("cannot write bob sum: %w", err)
+		}
+	}
+	return nil
+}
+
+// selectTarget selects the target triple from the '--target' flag or the legacy '--platform' and '--arch' flags.
+func selectTarget(gFlags *flags.GlobalFlags) (*mod.Triple, error) {
+	if gFlags.Target != "" {
+		target, err := mod.ParseTriple(gFlags.Target)
+		if err!=nil {
+			return nil, fmt.Errorf("invalid target: %w", err)
+		}
+		return target, nil
+	}
+	target, err := mod.PlatformTriple(gFlags.Platform, gFlags.Arch)
+	if err!=nil {
+		return nil, fmt.Errorf("invalid platform: %w", err)
+	}
+	return target, nil
+}
+
+// reportSkipped prints a summary of the invalid entries skipped while loading the module.
+func reportSkipped(module *mod.Mod, json bool) {
+	skips := module.Invalid()
+	if len(skips) < 1 {
+		return
+	}
+	report.NewNotifier(json).Warn(
+		fmt.Sprintf("skipped '%d' invalid entries (use --strict to fail instead)", len(skips)),
+		"module", module.Module, "entries", mod.Describe(skips),
+	)
+}
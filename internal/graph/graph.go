@@ -0,0 +1,209 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+const EXTERNAL_PREFIX = "@external:"
+
+type NODE_TYPE int64
+const (
+	NODE_PACK NODE_TYPE = iota
+	NODE_EXTERNAL
+)
+
+// Node is a pack or external in the dependency graph. Nodes are identified by their import path.
+type Node struct {
+	Type NODE_TYPE
+	Path string
+	Deps []*Node
+}
+
+// ResolveFunc returns the import paths of all direct dependencies of the specified pack.
+type ResolveFunc func(path string) ([]string, error)
+
+// Graph is the acyclic dependency graph of a root pack.
+type Graph struct {
+	Root *Node
+	Nodes map[string]*Node
+	order []*Node
+}
+
+// CycleError reports a dependency cycle with the full path that leads back to the first pack.
+type CycleError struct {
+	Path []string
+}
+
+func (c *CycleError) Error() string {
+	return fmt.Sprintf("dependency cycle detected: %s", strings.Join(c.Path, " -> "))
+}
+
+// ParseExternal splits an external import path '<module>/@external:<name>' into module and name.
+func ParseExternal(path string) (module string, name string, ok bool) {
+	return strings.Cut(path, "/"+EXTERNAL_PREFIX)
+}
+
+// Build walks the dependencies of the root pack with the resolver and builds the dependency graph.
+// Every pack is resolved exactly once, cycles are reported as CycleError.
+func Build(root string, resolve ResolveFunc) (*Graph, error) {
+	graph := &Graph{
+		Root: nil,
+		Nodes: map[string]*Node{},
+		order: []*Node{},
+	}
+
+	rootNode, err := graph.visit(root, resolve, []string{})
+	if err!=nil {
+		return nil, err
+	}
+	graph.Root = rootNode
+	return graph, nil
+}
+
+// visit resolves the node and its dependencies depth first. The stack contains the paths of all packs
+// currently being visited, encountering one of them again means that the graph contains a cycle.
+func (g *Graph) visit(path string, resolve ResolveFunc, stack []string) (*Node, error) {
+	for i, visiting := range stack {
+		if visiting == path {
+			cycle := append([]string{}, stack[i:]...)
+			return nil, &CycleError{Path: append(cycle, path)}
+		}
+	}
+	if node, ok := g.Nodes[path]; ok {
+		return node, nil
+	}
+
+	if _, _, ok := ParseExternal(path); ok {
+		node := &Node{Type: NODE_EXTERNAL, Path: path, Deps: []*Node{}}
+		g.Nodes[path] = node
+		g.order = append(g.order, node)
+		return node, nil
+	}
+
+	deps, err := resolve(path)
+	if err!=nil {
+		return nil, fmt.Errorf("failed to resolve pack '%s': %w", path, err)
+	}
+
+	node := &Node{Type: NODE_PACK, Path: path, Deps: []*Node{}}
+	stack = append(stack, path)
+	for _, dep := range deps {
+		depNode, err := g.visit(dep, resolve, stack)
+		if err!=nil {
+			return nil, err
+		}
+		node.Deps = append(node.Deps, depNode)
+	}
+
+	g.Nodes[path] = node
+	g.order = append(g.order, node)
+	return node, nil
+}
+
+// Order returns all nodes in topological order, every node is placed after all of its dependencies.
+func (g *Graph) Order() []*Node {
+	return g.order
+}
+
+// Closure returns all direct and transitive dependencies of the node in topological order.
+func (g *Graph) Closure(node *Node) []*Node {
+	reachable := map[*Node]bool{}
+	var walk func(*Node)
+	walk = func(n *Node) {
+		for _, dep := range n.Deps {
+			if !reachable[dep] {
+				reachable[dep] = true
+				walk(dep)
+			}
+		}
+	}
+	walk(node)
+
+	closure := []*Node{}
+	for _, n := range g.order {
+		if reachable[n] {
+			closure = append(closure, n)
+		}
+	}
+	return closure
+}
+
+// Depths returns the length of the shortest path from the node to every node reachable from it.
+func (g *Graph) Depths(from *Node) map[*Node]int {
+	depths := map[*Node]int{from: 0}
+	queue := []*Node{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dep := range current.Deps {
+			if _, ok := depths[dep]; !ok {
+				depths[dep] = depths[current]+1
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return depths
+}
+
+// ShortestPaths returns every shortest path from the node to the target node (both included).
+// The result is empty if the target is not reachable.
+func (g *Graph) ShortestPaths(from *Node, to *Node) [][]*Node {
+	depths := map[*Node]int{from: 0}
+	parents := map[*Node][]*Node{}
+	queue := []*Node{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			break
+		}
+		for _, dep := range current.Deps {
+			depth, ok := depths[dep]
+			if !ok {
+				depths[dep] = depths[current]+1
+				queue = append(queue, dep)
+			} else if depth != depths[current]+1 {
+				continue
+			}
+			parents[dep] = append(parents[dep], current)
+		}
+	}
+	if _, ok := depths[to]; !ok {
+		return [][]*Node{}
+	}
+
+	paths := [][]*Node{}
+	var walk func(*Node, []*Node)
+	walk = func(n *Node, suffix []*Node) {
+		suffix = append([]*Node{n}, suffix...)
+		if n == from {
+			paths = append(paths, suffix)
+			return
+		}
+		for _, parent := range parents[n] {
+			walk(parent, suffix)
+		}
+	}
+	walk(to, []*Node{})
+	return paths
+}
@@ -0,0 +1,166 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// resolver resolves the dependencies from the adjacency list.
+func resolver(deps map[string][]string) ResolveFunc {
+	return func(path string) ([]string, error) {
+		pathDeps, ok := deps[path]
+		if !ok {
+			return nil, fmt.Errorf("unknown pack '%s'", path)
+		}
+		return pathDeps, nil
+	}
+}
+
+// TestBuildCycle ensures that cycles are reported with the path leading back to the first pack of the cycle.
+func TestBuildCycle(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+		deps map[string][]string
+		want []string
+	}{
+		{
+			name: "self",
+			root: "a",
+			deps: map[string][]string{"a": {"a"}},
+			want: []string{"a", "a"},
+		},
+		{
+			name: "direct",
+			root: "a",
+			deps: map[string][]string{"a": {"b"}, "b": {"a"}},
+			want: []string{"a", "b", "a"},
+		},
+		{
+			name: "nested",
+			root: "root",
+			deps: map[string][]string{
+				"root": {"a"}, "a": {"x", "b"}, "x": {}, "b": {"c"}, "c": {"a"},
+			},
+			want: []string{"a", "b", "c", "a"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Build(test.root, resolver(test.deps))
+			var cycleErr *CycleError
+			if !errors.As(err, &cycleErr) {
+				t.Fatalf("expected cycle error got '%v'", err)
+			}
+			if !slices.Equal(cycleErr.Path, test.want) {
+				t.Errorf("expected cycle '%v' got '%v'", test.want, cycleErr.Path)
+			}
+		})
+	}
+}
+
+// TestBuildDiamond ensures that shared dependencies are not reported as cycle and resolved once.
+func TestBuildDiamond(t *testing.T) {
+	resolved := map[string]int{}
+	deps := resolver(map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}, "d": {}})
+	graph, err := Build("a", func(path string) ([]string, error) {
+		resolved[path]++
+		return deps(path)
+	})
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if resolved["d"] != 1 {
+		t.Errorf("expected 'd' to be resolved once got '%d'", resolved["d"])
+	}
+	if order := graph.Order(); order[len(order)-1] != graph.Root {
+		t.Errorf("expected root to be ordered last")
+	}
+}
+
+// TestShortestPaths ensures that all shortest paths are returned and longer paths are excluded.
+func TestShortestPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		deps map[string][]string
+		to string
+		want [][]string
+	}{
+		{
+			name: "root",
+			deps: map[string][]string{"a": {}},
+			to: "a",
+			want: [][]string{{"a"}},
+		},
+		{
+			name: "diamond",
+			deps: map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}, "d": {}},
+			to: "d",
+			want: [][]string{{"a", "b", "d"}, {"a", "c", "d"}},
+		},
+		{
+			name: "longer paths",
+			deps: map[string][]string{"a": {"b", "d"}, "b": {"c"}, "c": {"d"}, "d": {}},
+			to: "d",
+			want: [][]string{{"a", "d"}},
+		},
+		{
+			name: "external",
+			deps: map[string][]string{"a": {"b"}, "b": {"example.com/m/@external:zlib"}},
+			to: "example.com/m/@external:zlib",
+			want: [][]string{{"a", "b", "example.com/m/@external:zlib"}},
+		},
+		{
+			name: "unreachable",
+			deps: map[string][]string{"a": {"b"}, "b": {}, "c": {"b"}},
+			to: "c",
+			want: [][]string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graph, err := Build("a", resolver(test.deps))
+			if err!=nil {
+				t.Fatal(err)
+			}
+			to, ok := graph.Nodes[test.to]
+			if !ok {
+				to = &Node{Type: NODE_PACK, Path: test.to, Deps: []*Node{}}
+			}
+
+			got := [][]string{}
+			for _, path := range graph.ShortestPaths(graph.Root, to) {
+				nodes := []string{}
+				for _, node := range path {
+					nodes = append(nodes, node.Path)
+				}
+				got = append(got, nodes)
+			}
+			slices.SortFunc(got, slices.Compare)
+			if !slices.EqualFunc(got, test.want, slices.Equal) {
+				t.Errorf("expected paths '%v' got '%v'", test.want, got)
+			}
+		})
+	}
+}
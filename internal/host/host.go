@@ -0,0 +1,278 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package host
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	modcfg "github.com/megakuul/bob/pkg/mod"
+)
+
+// compilers contains the compiler drivers probed on the host in order of preference.
+var compilers = []struct {
+	driver string
+	flavor string
+}{
+	{driver: "g++", flavor: "gcc"},
+	{driver: "clang++", flavor: "clang"},
+}
+
+// Toolchain describes a toolchain installation found on the host.
+type Toolchain struct {
+	Flavor string
+	Version string
+	Triple string
+	Config modcfg.Toolchain
+}
+
+// Probe searches the host for a gcc or clang installation and resolves all toolchain artifacts
+// (start files, libc, libstdc++) through the '-print-file-name' option of the compiler driver.
+// If a flavor is specified, only compilers of this flavor are considered.
+func Probe(ctx context.Context, flavor string) (*Toolchain, error) {
+	for _, candidate := range compilers {
+		if flavor != "" && candidate.flavor != flavor {
+			continue
+		}
+		compiler, err := exec.LookPath(candidate.driver)
+		if err!=nil {
+			slog.Debug(fmt.Sprintf("compiler '%s' not found on host: %v", candidate.driver, err))
+			continue
+		}
+		return probeCompiler(ctx, compiler, candidate.flavor)
+	}
+	if flavor != "" {
+		return nil, fmt.Errorf("no compiler of flavor '%s' found on host", flavor)
+	}
+	return nil, fmt.Errorf("no compiler found on host; install 'g++' or 'clang++'")
+}
+
+type detection struct {
+	chain *Toolchain
+	err error
+}
+
+var (
+	detectionLock sync.Mutex
+	detections = map[string]detection{}
+)
+
+// Detect probes the host once per flavor and returns the cached result on subsequent calls.
+func Detect(flavor string) (*Toolchain, error) {
+	detectionLock.Lock()
+	defer detectionLock.Unlock()
+
+	if result, ok := detections[flavor]; ok {
+		return result.chain, result.err
+	}
+	chain, err := Probe(context.Background(), flavor)
+	detections[flavor] = detection{chain: chain, err: err}
+	return chain, err
+}
+
+// Synthesize completes an automatic toolchain with the toolchain detected on the host.
+// Artifacts, platforms and archs declared explicitly take precedence over the detected ones.
+func Synthesize(cfg modcfg.Toolchain) (*modcfg.Toolchain, error) {
+	detected, err := Detect(cfg.Flavor)
+	if err!=nil {
+		return nil, err
+	}
+	chain := detected.Config
+
+	if cfg.Name == "" {
+		cfg.Name = chain.Name
+	}
+	if cfg.Flavor == "" {
+		cfg.Flavor = chain.Flavor
+	}
+	if cfg.Target == "" {
+		cfg.Target = chain.Target
+	}
+	if len(cfg.Platforms) < 1 {
+		cfg.Platforms = chain.Platforms
+	}
+	if len(cfg.Archs) < 1 {
+		cfg.Archs = chain.Archs
+	}
+	for _, path := range []struct{ cfg *modcfg.Path; detected modcfg.Path }{
+		{&cfg.Compiler, chain.Compiler},
+		{&cfg.Linker, chain.Linker},
+		{&cfg.Interpreter, chain.Interpreter},
+		{&cfg.Archiver, chain.Archiver},
+		{&cfg.Stdlib, chain.Stdlib},
+		{&cfg.Stdpplib, chain.Stdpplib},
+	} {
+		if path.cfg.URL == "" {
+			*path.cfg = path.detected
+		}
+	}
+	if len(cfg.Supportlibs) < 1 {
+		cfg.Supportlibs = chain.Supportlibs
+	}
+	if len(cfg.Startfiles) < 1 {
+		cfg.Startfiles = chain.Startfiles
+	}
+	if len(cfg.SharedStartfiles) < 1 {
+		cfg.SharedStartfiles = chain.SharedStartfiles
+	}
+	return &cfg, nil
+}
+
+// probeCompiler resolves the toolchain of the compiler driver.
+func probeCompiler(ctx context.Context, compiler, flavor string) (*Toolchain, error) {
+	version, err := query(ctx, compiler, "-dumpversion")
+	if err!=nil {
+		return nil, err
+	}
+	triple, err := query(ctx, compiler, "-dumpmachine")
+	if err!=nil {
+		return nil, err
+	}
+
+	linker, err := exec.LookPath("ld")
+	if err!=nil {
+		return nil, fmt.Errorf("linker not found on host: %w", err)
+	}
+	archiver, err := exec.LookPath("ar")
+	if err!=nil {
+		return nil, fmt.Errorf("archiver not found on host: %w", err)
+	}
+	interpreter, err := findInterpreter(compiler, linker)
+	if err!=nil {
+		return nil, err
+	}
+
+	files := map[string]modcfg.Path{}
+	for _, name := range []string{
+		"crt1.o", "crti.o", "crtn.o", "crtbegin.o", "crtend.o", "crtbeginS.o", "crtendS.o",
+		"libc.so.6", "libstdc++.so.6", "libgcc_s.so.1",
+	} {
+		files[name], err = findFile(ctx, compiler, name)
+		if err!=nil {
+			return nil, err
+		}
+	}
+
+	return &Toolchain{
+		Flavor: flavor,
+		Version: version,
+		Triple: triple,
+		Config: modcfg.Toolchain{
+			Name: flavor,
+			Flavor: flavor,
+			Target: triple,
+			Platforms: []string{runtime.GOOS},
+			Archs: []string{runtime.GOARCH},
+			Compiler: artifact(compiler),
+			Linker: artifact(linker),
+			Interpreter: artifact(interpreter),
+			Archiver: artifact(archiver),
+			Stdlib: files["libc.so.6"],
+			Stdpplib: files["libstdc++.so.6"],
+			Supportlibs: []modcfg.Path{files["libgcc_s.so.1"]},
+			Startfiles: []modcfg.Path{
+				files["crt1.o"], files["crti.o"], files["crtn.o"], files["crtbegin.o"], files["crtend.o"],
+			},
+			SharedStartfiles: []modcfg.Path{
+				files["crti.o"], files["crtn.o"], files["crtbeginS.o"], files["crtendS.o"],
+			},
+		},
+	}, nil
+}
+
+// findFile resolves the location of a file in the library search path of the compiler.
+func findFile(ctx context.Context, compiler, name string) (modcfg.Path, error) {
+	path, err := query(ctx, compiler, "-print-file-name="+name)
+	if err!=nil {
+		return modcfg.Path{}, err
+	}
+	// the driver echoes the plain name if the file is not found in any search directory.
+	if !filepath.IsAbs(path) {
+		return modcfg.Path{}, fmt.Errorf("compiler '%s' cannot locate '%s'", compiler, name)
+	}
+	return artifact(filepath.Clean(path)), nil
+}
+
+// findInterpreter reads the dynamic loader of the host from the program header of one of the host binaries.
+// Wrapped toolchains may provide scripts instead of binaries, therefore all candidates are tried.
+func findInterpreter(candidates ...string) (string, error) {
+	for _, candidate := range candidates {
+		path, err := filepath.EvalSymlinks(candidate)
+		if err!=nil {
+			continue
+		}
+		interpreter, err := readInterpreter(path)
+		if err!=nil {
+			slog.Debug(fmt.Sprintf("cannot read interpreter of '%s': %v", path, err))
+			continue
+		}
+		return interpreter, nil
+	}
+	return "", fmt.Errorf("cannot determine the dynamic loader of the host")
+}
+
+// readInterpreter reads the PT_INTERP segment of an elf binary.
+func readInterpreter(path string) (string, error) {
+	file, err := elf.Open(path)
+	if err!=nil {
+		return "", err
+	}
+	defer file.Close()
+
+	for _, prog := range file.Progs {
+		if prog.Type != elf.PT_INTERP {
+			continue
+		}
+		data, err := io.ReadAll(prog.Open())
+		if err!=nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\x00"), nil
+	}
+	return "", fmt.Errorf("binary is statically linked")
+}
+
+// query runs the compiler driver with the argument and returns the trimmed output.
+func query(ctx context.Context, compiler, arg string) (string, error) {
+	stdout := bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, compiler, arg)
+	cmd.Stdout = &stdout
+	if err:=cmd.Run(); err!=nil {
+		return "", fmt.Errorf("failed to query '%s %s': %w", compiler, arg, err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// artifact converts an absolute host path into a local artifact.
+func artifact(path string) modcfg.Path {
+	return modcfg.Path{
+		URL: "file://" + filepath.Dir(path),
+		Path: filepath.Base(path),
+	}
+}
@@ -41,12 +41,11 @@ func downloadGit(ctx context.Context, url, out string, clean bool) error {
 		return nil
 	}
 
-	urlSegments := strings.SplitN(url, "@", 2)
-	if len(urlSegments) != 2 {
-		return fmt.Errorf("expected 'git://<host>/<path>@<revision>' found %d '@' characters", len(urlSegments))
+	base, revision := SplitRevision(url)
+	if revision == "" {
+		return fmt.Errorf("expected 'git://<host>/<path>@<revision>' found no revision in '%s'", url)
 	}
-	httpUrl := fmt.Sprintf("https://%s", strings.TrimPrefix("git://", urlSegments[0]))
-	revision := urlSegments[1]
+	httpUrl := fmt.Sprintf("https://%s", strings.TrimPrefix(base, "git://"))
 
 	repo, err := git.PlainCloneContext(ctx, out, false, &git.CloneOptions{URL: httpUrl})
 	if err!=nil {
@@ -76,3 +75,16 @@ func downloadGit(ctx context.Context, url, out string, clean bool) error {
 
 	return nil
 }
+
+// SplitRevision splits a 'git://<host>/<path>@<revision>' url into the repository url and the revision.
+// Urls of other schemes are not versioned and result in an empty revision.
+func SplitRevision(url string) (base string, revision string) {
+	if !strings.HasPrefix(url, "git://") {
+		return url, ""
+	}
+	index := strings.LastIndex(url, "@")
+	if index < 0 {
+		return url, ""
+	}
+	return url[:index], url[index+1:]
+}
@@ -22,14 +22,17 @@ package loader
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/megakuul/bob/pkg/sum"
 	"github.com/mholt/archives"
 )
 
@@ -54,6 +57,10 @@ type Loader struct {
 
 	jobsLock sync.Mutex
 	jobs map[string]job
+
+	sumLock sync.Mutex
+	sum *sum.Sum
+	sumModified bool
 }
 
 type LoaderOption func(*Loader)
@@ -64,6 +71,9 @@ func NewLoader(ctx context.Context, opts ...LoaderOption) *Loader {
 		rootPath: "./.bobcache",
 		jobsLock: sync.Mutex{},
 		jobs: map[string]job{},
+		sumLock: sync.Mutex{},
+		sum: nil,
+		sumModified: false,
 	}
 
 	for _, opt := range opts {
@@ -80,31 +90,68 @@ func WithRootPath(path string) LoaderOption {
 	}
 }
 
+// WithSum enables verification of all loaded assets against the checksum database.
+// Checksums of assets not present in the database are added to it.
+func WithSum(sum *sum.Sum) LoaderOption {
+	return func(l *Loader) {
+		l.sum = sum
+	}
+}
+
+// Sum returns the checksum database and reports whether new entries were added to it.
+func (l *Loader) Sum() (*sum.Sum, bool) {
+	l.sumLock.Lock()
+	defer l.sumLock.Unlock()
+	return l.sum, l.sumModified
+}
+
+// ParseType determines the load type of an asset based on the scheme of its url.
+func ParseType(url string) (LOAD_TYPE, error) {
+	switch {
+	case strings.HasPrefix(url, "git://"):
+		return LOAD_GIT, nil
+	case strings.HasPrefix(url, "http://"), strings.HasPrefix(url, "https://"):
+		return LOAD_HTTP, nil
+	case strings.HasPrefix(url, "file://"):
+		return LOAD_FILE, nil
+	default:
+		return 0, fmt.Errorf("unsupported url scheme in '%s'", url)
+	}
+}
+
 // Load() checks whether the requested asset is currently being downloaded. If this is the case, Load() waits
 // until the download is complete. If not, Load() starts the download itself and waits until it is complete.
-// The asset is extracted to $rootPath/$typ-$sha256(url)/...
+// The asset is extracted to $rootPath/$typ-$sha256(url)/... and verified against the checksum database if set.
 func (l *Loader) Load(typ LOAD_TYPE, url string, clean bool) (string, error) {
-	hash := sha256.Sum256([]byte(fmt.Sprintf("%d-%s", typ, url)))
-	outputPath := filepath.Join(l.rootPath, string(hash[:]))
+	rawHash := sha256.Sum256([]byte(fmt.Sprintf("%d-%s", typ, url)))
+	hash := hex.EncodeToString(rawHash[:])
+	outputPath := filepath.Join(l.rootPath, hash)
 
 	l.jobsLock.Lock()
-	activeJob, ok := l.jobs[string(hash[:])]
+	activeJob, ok := l.jobs[hash]
 	if !ok {
 		errGroup, _ := errgroup.WithContext(l.rootCtx)
 		errGroup.Go(func() error {
+			_, err := os.Lstat(outputPath)
+			cached := err==nil && !clean
+
 			switch typ {
 			case LOAD_GIT:
-				return downloadGit(l.rootCtx, url, outputPath, clean)
+				err = downloadGit(l.rootCtx, url, outputPath, clean)
 			case LOAD_HTTP:
-				return downloadHTTP(l.rootCtx, url, outputPath, clean)
+				err = downloadHTTP(l.rootCtx, url, outputPath, clean)
 			case LOAD_FILE:
-				return downloadFile(l.rootCtx, url, outputPath, clean)
+				err = downloadFile(l.rootCtx, url, outputPath, clean)
 			default:
-				return fmt.Errorf("unsupported load type '%d'", typ)
+				err = fmt.Errorf("unsupported load type '%d'", typ)
+			}
+			if err!=nil {
+				return err
 			}
+			return l.verify(url, outputPath, cached)
 		})
 		activeJob = job{typ: typ, url: url, out: outputPath, group: errGroup}
-		l.jobs[string(hash[:])] = activeJob
+		l.jobs[hash] = activeJob
 	}
 	l.jobsLock.Unlock()
 	return outputPath, activeJob.group.Wait()
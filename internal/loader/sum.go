@@ -0,0 +1,198 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package loader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VERIFIED_SUFFIX is appended to the cache path of an asset to store the checksum it was last verified against.
+const VERIFIED_SUFFIX = ".verified"
+
+// Verifiable checks if the content of the url is pinned in the checksum database. Local directories are
+// excluded, as they are referenced in place and change together with the host (e.g. system toolchains).
+func Verifiable(url string) bool {
+	typ, err := ParseType(url)
+	if err!=nil || typ != LOAD_FILE {
+		return true
+	}
+	info, err := os.Stat(strings.TrimPrefix(url, "file://"))
+	return err!=nil || !info.IsDir()
+}
+
+// verify checks the content hash of a loaded asset against the checksum database. Assets without entry are
+// hashed and added to the database. Cached assets are only trusted without hashing if their marker records
+// that they were verified against the current entry and their files did not change since (by fingerprint).
+// Assets that do not match their entry are removed from the cache to ensure they are fetched again.
+func (l *Loader) verify(url, path string, cached bool) error {
+	if l.sum == nil || !Verifiable(url) {
+		return nil
+	}
+
+	l.sumLock.Lock()
+	expected, ok := l.sum.Paths[url]
+	l.sumLock.Unlock()
+	marker := path+VERIFIED_SUFFIX
+	if ok && cached {
+		verified, err := os.ReadFile(marker)
+		if err==nil {
+			if state, err := fingerprint(path); err==nil && string(verified) == expected+"\n"+state {
+				return nil
+			}
+		}
+	}
+
+	hash, err := HashDir(path)
+	if err!=nil {
+		return fmt.Errorf("failed to hash '%s': %w", url, err)
+	}
+
+	if !ok {
+		slog.Debug(fmt.Sprintf("adding checksum of '%s' to sum...", url))
+		l.sumLock.Lock()
+		l.sum.Paths[url] = hash
+		l.sumModified = true
+		l.sumLock.Unlock()
+		return writeMarker(marker, path, hash)
+	}
+
+	if hash != expected {
+		if err:=os.Remove(marker); err!=nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err:=os.RemoveAll(path); err!=nil {
+			return err
+		}
+		return fmt.Errorf("checksum mismatch for '%s': expected '%s' got '%s'", url, expected, hash)
+	}
+	return writeMarker(marker, path, hash)
+}
+
+// writeMarker records that the asset was verified against the hash together with the fingerprint of its files.
+func writeMarker(marker, path, hash string) error {
+	state, err := fingerprint(path)
+	if err!=nil {
+		return err
+	}
+	return os.WriteFile(marker, []byte(hash+"\n"+state), 0644)
+}
+
+// fingerprint cheaply identifies the state of the directory by the path, mode, size and modification time of
+// every entry. It changes whenever a file is modified, added or removed, without reading the file contents.
+func fingerprint(path string) (string, error) {
+	root, err := filepath.EvalSymlinks(path)
+	if err!=nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	err = filepath.WalkDir(root, func(entryPath string, entry fs.DirEntry, err error) error {
+		if err!=nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err!=nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, entryPath)
+		if err!=nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s %s %d %d\n", filepath.ToSlash(rel), info.Mode(), info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err!=nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// HashDir computes a deterministic content hash of the directory. Every entry contributes its relative path,
+// type and content (symlinks contribute their target instead of being followed). The '.git' directory of
+// cloned repositories is excluded as its content depends on the clone operation.
+func HashDir(path string) (string, error) {
+	root, err := filepath.EvalSymlinks(path)
+	if err!=nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	err = filepath.WalkDir(root, func(entryPath string, entry fs.DirEntry, err error) error {
+		if err!=nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, entryPath)
+		if err!=nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		info, err := entry.Info()
+		if err!=nil {
+			return err
+		}
+		switch {
+		case entry.IsDir():
+			fmt.Fprintf(hash, "d %s\n", filepath.ToSlash(rel))
+		case entry.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(entryPath)
+			if err!=nil {
+				return err
+			}
+			fmt.Fprintf(hash, "l %s %s\n", filepath.ToSlash(rel), target)
+		case entry.Type().IsRegular():
+			fileHash, err := HashFile(entryPath)
+			if err!=nil {
+				return err
+			}
+			fmt.Fprintf(hash, "f %s %t %s\n", filepath.ToSlash(rel), info.Mode()&0111 != 0, fileHash)
+		}
+		return nil
+	})
+	if err!=nil {
+		return "", err
+	}
+	return "sha256:"+hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// HashFile returns the hex encoded sha256 hash of the file content.
+func HashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err!=nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err!=nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
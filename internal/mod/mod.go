@@ -22,181 +22,250 @@ package mod
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 
+	"github.com/megakuul/bob/internal/host"
 	modcfg "github.com/megakuul/bob/pkg/mod"
 )
 
-type PLATFORM int64
-const (
-	PLATFORM_UNIX PLATFORM = iota
-	PLATFORM_WINDOWS
-)
-
-var PLATFORMS = map[string]PLATFORM{
-	"unix": PLATFORM_UNIX,
-	"linux": PLATFORM_UNIX,
-	"windows": PLATFORM_WINDOWS,
-}
-
-type ARCH int64
-const (
-	ARCH_AMD64 ARCH = iota
-	ARCH_ARM64
-)
-
-var ARCHS = map[string]ARCH{
-	"amd64": ARCH_AMD64,
-	"arm64": ARCH_ARM64,
-}
-
 type Mod struct {
 	Module string
+	Target Triple
 	Toolchains map[string]Toolchain
 	Targets map[string]Target
 	Includes map[string]Include
 	Externals map[string]External
+	Profiles map[string]Profile
+	Skipped []Skip
 }
 
 // CreateMod loads and validates a configuration module into a internal Mod.
-// Only toolchains compatible with the platform / arch are included.
-func CreateMod(cfg *modcfg.Mod, platform PLATFORM, arch ARCH) (*Mod, error) {
-	toolchains, err := getToolchains(cfg.Toolchains, platform, arch)
+// Only toolchains compatible with the target are included, entries that are not loaded are recorded in Skipped.
+// In strict mode (enabled by the flag or the module) invalid entries fail the load instead of being skipped.
+func CreateMod(cfg *modcfg.Mod, target Triple, strict bool) (*Mod, error) {
+	skipped := []Skip{}
+
+	toolchains, skips, err := getToolchains(cfg.Toolchains, &target)
 	if err!=nil {
 		return nil, fmt.Errorf("failed to load toolchains: %w", err)
 	}
+	skipped = append(skipped, skips...)
 
-	targets, err := getTargets(cfg.Targets, toolchains)
+	targets, skips, err := getTargets(cfg.Targets, toolchains, skipped)
 	if err!=nil {
 		return nil, fmt.Errorf("failed to load targets: %w", err)
 	}
+	skipped = append(skipped, skips...)
 
-	includes, err := getIncludes(cfg.Includes)
+	includes, skips, err := getIncludes(cfg.Includes)
 	if err!=nil {
 		return nil, fmt.Errorf("failed to load includes: %w", err)
 	}
+	skipped = append(skipped, skips...)
 
-	externals, err := getExternals(cfg.Externals)
+	externals, skips, err := getExternals(cfg.Externals)
 	if err!=nil {
 		return nil, fmt.Errorf("failed to load externals: %w", err)
 	}
+	skipped = append(skipped, skips...)
+
+	profiles, skips, err := getProfiles(cfg.Profiles)
+	if err!=nil {
+		return nil, fmt.Errorf("failed to load profiles: %w", err)
+	}
+	skipped = append(skipped, skips...)
+
+	if strict || cfg.Strict {
+		if err:=checkSkipped(cfg.Module, skipped); err!=nil {
+			return nil, err
+		}
+	}
 
 	return &Mod{
 		Module: cfg.Module,
+		Target: target,
 		Toolchains: toolchains,
 		Targets: targets,
 		Includes: includes,
 		Externals: externals,
+		Profiles: profiles,
+		Skipped: skipped,
 	}, nil
 }
 
-// getToolchains loads and validates all toolchains that match with the wanted platform & architecture.
-func getToolchains(cfgChains []modcfg.Toolchain, platform PLATFORM, arch ARCH) (map[string]Toolchain, error) {
+// getToolchains loads and validates all toolchains that can build for the wanted target.
+func getToolchains(cfgChains []modcfg.Toolchain, target *Triple) (map[string]Toolchain, []Skip, error) {
 	chains := map[string]Toolchain{}
+	skips := []Skip{}
 	for _, cfgChain := range cfgChains {
-		ok, err := checkArch(cfgChain.Archs, arch)
-		if err!=nil {
-			return nil, err
-		}
-		if !ok {
-			slog.Debug(fmt.Sprintf(
-				"toolchain '%s' does not support current arch; skipping toolchain...", cfgChain.Name,
-			))
-			continue
+		if cfgChain.Auto {
+			detected, err := host.Synthesize(cfgChain)
+			if err!=nil {
+				slog.Warn(fmt.Sprintf("cannot detect host toolchain: %v; skipping toolchain '%s'...", err, cfgChain.Name))
+				skips = append(skips, Skip{
+					Entry: ENTRY_TOOLCHAIN, Name: cfgChain.Name, Reason: fmt.Errorf("cannot detect host toolchain: %w", err),
+				})
+				continue
+			}
+			cfgChain = *detected
 		}
 
-		ok, err = checkPlatform(cfgChain.Platforms, platform)
+		ok, err := checkTarget(&cfgChain, target)
 		if err!=nil {
-			return nil, err
+			slog.Warn(fmt.Sprintf("%v; skipping toolchain '%s'...", err, cfgChain.Name))
+			skips = append(skips, Skip{Entry: ENTRY_TOOLCHAIN, Name: cfgChain.Name, Reason: err})
+			continue
 		}
 		if !ok {
 			slog.Debug(fmt.Sprintf(
-				"toolchain '%s' does not support current platform; skipping toolchain...", cfgChain.Name,
+				"toolchain '%s' does not support target '%s'; skipping toolchain...", cfgChain.Name, target,
 			))
+			skips = append(skips, Skip{
+				Entry: ENTRY_TOOLCHAIN,
+				Name: cfgChain.Name,
+				Reason: fmt.Errorf("toolchain does not support target '%s'", target),
+				Incompatible: true,
+			})
 			continue
 		}
 
 		chain, err := createToolchain(&cfgChain)
 		if err!=nil {
 			slog.Warn(fmt.Sprintf("%v; skipping toolchain '%s'...", err, cfgChain.Name))
+			skips = append(skips, Skip{Entry: ENTRY_TOOLCHAIN, Name: cfgChain.Name, Reason: err})
 			continue
 		}
 		chains[cfgChain.Name] = *chain
 	}
 
-	return chains, nil
+	return chains, skips, nil
+}
+
+// checkTarget checks if the toolchain can build for the target. Toolchains declaring a target triple are
+// matched against it, others are matched by their legacy platforms and archs.
+func checkTarget(cfgChain *modcfg.Toolchain, target *Triple) (bool, error) {
+	if cfgChain.Target != "" {
+		triple, err := ParseTriple(cfgChain.Target)
+		if err!=nil {
+			return false, err
+		}
+		return triple.Matches(target), nil
+	}
+	archOk, err := checkArch(cfgChain.Archs, target.Arch)
+	if err!=nil {
+		return false, err
+	}
+	platformOk, err := checkPlatform(cfgChain.Platforms, target.OS)
+	if err!=nil {
+		return false, err
+	}
+	return archOk && platformOk, nil
 }
 
 // checkArch checks if the specified architecture is compatible with the configuration archs.
-func checkArch(cfgArchs []string, arch ARCH) (bool, error) {
+// Unknown configuration archs are reported as error, as they are most likely typos.
+func checkArch(cfgArchs []string, arch string) (bool, error) {
+	compatible := false
 	for _, cfgArch := range cfgArchs {
-		cfgArchType, ok := ARCHS[cfgArch]
+		cfgTripleArch, ok := ARCHS[cfgArch]
 		if !ok {
-			slog.Warn(fmt.Sprintf("unknown architecture '%s' in toolchain detected...", cfgArch))
-			continue
+			return false, fmt.Errorf("unknown architecture '%s'", cfgArch)
 		}
-		if cfgArchType == arch {
-			return true, nil
+		if cfgTripleArch == arch {
+			compatible = true
 		}
 	}
-	return false, nil
+	return compatible, nil
 }
 
-// checkPlatform checks if the specified platform is compatible with the configuration platforms.
-func checkPlatform(cfgPlatforms []string, platform PLATFORM) (bool, error) {
+// checkPlatform checks if the specified operating system is covered by the configuration platforms.
+// Unknown configuration platforms are reported as error, as they are most likely typos.
+func checkPlatform(cfgPlatforms []string, os string) (bool, error) {
+	compatible := false
 	for _, cfgPlatform := range cfgPlatforms {
-		cfgPlatformType, ok := PLATFORMS[cfgPlatform]
+		cfgOses, ok := PLATFORMS[cfgPlatform]
 		if !ok {
-			slog.Warn(fmt.Sprintf("unknown platform '%s' in toolchain detected...", cfgPlatform))
-			continue
+			return false, fmt.Errorf("unknown platform '%s'", cfgPlatform)
 		}
-		if cfgPlatformType == platform {
-			return true, nil
+		if slices.Contains(cfgOses, os) {
+			compatible = true
 		}
 	}
-	return false, nil
+	return compatible, nil
 }
 
 
 // getTargets loads and validates all configured targets that are compatible with the loaded toolchains.
-func getTargets(cfgTargets []modcfg.Target, toolchains map[string]Toolchain) (map[string]Target, error) {
+// Targets are incompatible if all their toolchains were skipped as incompatible.
+func getTargets(cfgTargets []modcfg.Target, toolchains map[string]Toolchain, chainSkips []Skip) (map[string]Target, []Skip, error) {
 	targets := map[string]Target{}
+	skips := []Skip{}
 	for _, cfgTarget := range cfgTargets {
 		target, err := createTarget(&cfgTarget, toolchains)
 		if err!=nil {
-			slog.Warn(fmt.Sprintf("%v; skipping target '%s'...", err, cfgTarget.Pack))
+			incompatible := len(cfgTarget.Toolchains) > 0 && !slices.ContainsFunc(cfgTarget.Toolchains, func(name string) bool {
+				return !slices.ContainsFunc(chainSkips, func(skip Skip) bool {
+					return skip.Entry == ENTRY_TOOLCHAIN && skip.Name == name && skip.Incompatible
+				})
+			})
+			if incompatible {
+				slog.Debug(fmt.Sprintf("%v; skipping target '%s'...", err, cfgTarget.Pack))
+			} else {
+				slog.Warn(fmt.Sprintf("%v; skipping target '%s'...", err, cfgTarget.Pack))
+			}
+			skips = append(skips, Skip{Entry: ENTRY_TARGET, Name: cfgTarget.Pack, Reason: err, Incompatible: incompatible})
 			continue
 		}
 		targets[cfgTarget.Pack] = *target
 	}
-	return targets, nil
+	return targets, skips, nil
 }
 
 
 // getIncludes loads and validates all configured includes.
-func getIncludes(cfgIncludes []modcfg.Include) (map[string]Include, error) {
+func getIncludes(cfgIncludes []modcfg.Include) (map[string]Include, []Skip, error) {
 	includes := map[string]Include{}
+	skips := []Skip{}
 	for _, cfgInclude := range cfgIncludes {
 		include, err := createInclude(&cfgInclude)
 		if err!=nil {
 			slog.Warn(fmt.Sprintf("%v; skipping include '%s'...", err, cfgInclude.Mod))
+			skips = append(skips, Skip{Entry: ENTRY_INCLUDE, Name: cfgInclude.Mod, Reason: err})
 			continue
 		}
 		includes[cfgInclude.Mod] = *include
 	}
-	return includes, nil
+	return includes, skips, nil
 }
 
 // getExternals loads and validates all configured externals.
-func getExternals(cfgExternals []modcfg.External) (map[string]External, error) {
+func getExternals(cfgExternals []modcfg.External) (map[string]External, []Skip, error) {
 	externals := map[string]External{}
+	skips := []Skip{}
 	for _, cfgExternal := range cfgExternals {
 		external, err := createExternal(&cfgExternal)
 		if err!=nil {
 			slog.Warn(fmt.Sprintf("%v; skipping external '%s'...", err, cfgExternal.Name))
+			skips = append(skips, Skip{Entry: ENTRY_EXTERNAL, Name: cfgExternal.Name, Reason: err})
 			continue
 		}
 		externals[cfgExternal.Name] = *external
 	}
-	return externals, nil
+	return externals, skips, nil
 }
 
+// getProfiles loads and validates all configured profiles.
+func getProfiles(cfgProfiles []modcfg.Profile) (map[string]Profile, []Skip, error) {
+	profiles := map[string]Profile{}
+	skips := []Skip{}
+	for _, cfgProfile := range cfgProfiles {
+		profile, err := createProfile(&cfgProfile)
+		if err!=nil {
+			slog.Warn(fmt.Sprintf("%v; skipping profile '%s'...", err, cfgProfile.Name))
+			skips = append(skips, Skip{Entry: ENTRY_PROFILE, Name: cfgProfile.Name, Reason: err})
+			continue
+		}
+		profiles[cfgProfile.Name] = *profile
+	}
+	return profiles, skips, nil
+}
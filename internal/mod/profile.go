@@ -0,0 +1,66 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mod
+
+import (
+	"fmt"
+	"strings"
+
+	modcfg "github.com/megakuul/bob/pkg/mod"
+)
+
+// DEFAULT_PROFILE is the profile used if no profile is selected. It carries no flags unless it is declared.
+const DEFAULT_PROFILE = "default"
+
+type Profile struct {
+	Name string
+	CompilerFlags []string
+	LinkerFlags []string
+}
+
+func createProfile(profile *modcfg.Profile) (*Profile, error) {
+	if profile.Name == "" {
+		return nil, fmt.Errorf("profile does not specify a name")
+	}
+	// the profile name is used as directory in the cache.
+	if strings.ContainsAny(profile.Name, `/\.`) {
+		return nil, fmt.Errorf("profile name '%s' must not contain '/', '\\' or '.'", profile.Name)
+	}
+
+	return &Profile{
+		Name: profile.Name,
+		CompilerFlags: profile.CompilerFlags,
+		LinkerFlags: profile.LinkerFlags,
+	}, nil
+}
+
+// Profile returns the profile with the name or the default profile if the name is empty.
+func (m *Mod) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = DEFAULT_PROFILE
+	}
+	if profile, ok := m.Profiles[name]; ok {
+		return &profile, nil
+	}
+	if name == DEFAULT_PROFILE {
+		return &Profile{Name: DEFAULT_PROFILE}, nil
+	}
+	return nil, fmt.Errorf("profile '%s' is not defined in module '%s'", name, m.Module)
+}
@@ -0,0 +1,100 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mod
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ENTRY string
+const (
+	ENTRY_TOOLCHAIN ENTRY = "toolchain"
+	ENTRY_TARGET ENTRY = "target"
+	ENTRY_INCLUDE ENTRY = "include"
+	ENTRY_EXTERNAL ENTRY = "external"
+	ENTRY_PROFILE ENTRY = "profile"
+)
+
+// Skip describes a configured entry that was not loaded. Incompatible entries cannot be used for the target
+// (e.g. toolchains of another platform) and are skipped by design, all other skipped entries are invalid.
+type Skip struct {
+	Entry ENTRY
+	Name string
+	Reason error
+	Incompatible bool
+}
+
+// Invalid returns all skipped entries that are not incompatible with the target.
+func (m *Mod) Invalid() []Skip {
+	return invalid(m.Skipped)
+}
+
+// Skips returns all skipped entries of the kind.
+func (m *Mod) Skips(entry ENTRY) []Skip {
+	skips := []Skip{}
+	for _, skip := range m.Skipped {
+		if skip.Entry == entry {
+			skips = append(skips, skip)
+		}
+	}
+	return skips
+}
+
+func (s *Skip) Error() string {
+	return fmt.Sprintf("%s '%s': %v", s.Entry, s.Name, s.Reason)
+}
+
+func (s *Skip) Unwrap() error {
+	return s.Reason
+}
+
+// Describe lists the skipped entries together with the reason they were skipped.
+func Describe(skips []Skip) string {
+	entries := make([]string, len(skips))
+	for i, skip := range skips {
+		entries[i] = skip.Error()
+	}
+	return strings.Join(entries, "; ")
+}
+
+// invalid filters the skipped entries that are not incompatible with the target.
+func invalid(skips []Skip) []Skip {
+	invalid := []Skip{}
+	for _, skip := range skips {
+		if !skip.Incompatible {
+			invalid = append(invalid, skip)
+		}
+	}
+	return invalid
+}
+
+// checkSkipped reports all invalid entries of the module as a single error.
+func checkSkipped(module string, skips []Skip) error {
+	errs := []error{}
+	for _, skip := range invalid(skips) {
+		errs = append(errs, &skip)
+	}
+	if len(errs) < 1 {
+		return nil
+	}
+	return fmt.Errorf("module '%s' has '%d' invalid entries (strict mode):\n%w", module, len(errs), errors.Join(errs...))
+}
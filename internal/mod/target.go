@@ -25,8 +25,22 @@ import (
 	modcfg "github.com/megakuul/bob/pkg/mod"
 )
 
+type LINKAGE int64
+const (
+	LINKAGE_STATIC LINKAGE = iota
+	LINKAGE_SHARED
+	LINKAGE_BOTH
+)
+
+var LINKAGES = map[string]LINKAGE{
+	"static": LINKAGE_STATIC,
+	"shared": LINKAGE_SHARED,
+	"both": LINKAGE_BOTH,
+}
+
 type Target struct {
 	Library bool
+	Linkage LINKAGE
 	Toolchain *Toolchain
 }
 
@@ -34,6 +48,18 @@ func createTarget(target *modcfg.Target, toolchains map[string]Toolchain) (*Targ
 	output := &Target{
 		Toolchain: nil,
 		Library: target.Library,
+		Linkage: LINKAGE_STATIC,
+	}
+
+	if target.Linkage != "" {
+		linkage, ok := LINKAGES[target.Linkage]
+		if !ok {
+			return nil, fmt.Errorf("unknown linkage '%s'", target.Linkage)
+		}
+		if !target.Library {
+			return nil, fmt.Errorf("linkage '%s' is only supported for library targets", target.Linkage)
+		}
+		output.Linkage = linkage
 	}
 	
 	for _, toolchain := range target.Toolchains {
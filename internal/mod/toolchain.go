@@ -25,16 +25,52 @@ import (
 	modcfg "github.com/megakuul/bob/pkg/mod"
 )
 
+type FLAVOR int64
+const (
+	FLAVOR_GCC FLAVOR = iota
+	FLAVOR_CLANG
+)
+
+var FLAVORS = map[string]FLAVOR{
+	"gcc": FLAVOR_GCC,
+	"clang": FLAVOR_CLANG,
+}
+
 type Toolchain struct {
+	Name string
+	Flavor FLAVOR
+	Target string
+	Sysroot Artifact
 	Compiler Artifact
 	Linker Artifact
+	Interpreter Artifact
+	Archiver Artifact
 	Stdlib Artifact
 	Stdpplib Artifact
 	Supportlibs []Artifact
 	Startfiles []Artifact
+	SharedStartfiles []Artifact
 }
 
 func createToolchain(toolchain *modcfg.Toolchain) (*Toolchain, error) {
+	flavor := FLAVOR_GCC
+	if toolchain.Flavor != "" {
+		var ok bool
+		flavor, ok = FLAVORS[toolchain.Flavor]
+		if !ok {
+			return nil, fmt.Errorf("unknown flavor '%s'", toolchain.Flavor)
+		}
+	}
+
+	sysroot := &Artifact{}
+	if toolchain.Sysroot != nil {
+		var err error
+		sysroot, err = createArtifact(*toolchain.Sysroot)
+		if err!=nil {
+			return nil, fmt.Errorf("cannot create sysroot artifact: %w", err)
+		}
+	}
+
 	compiler, err := createArtifact(toolchain.Compiler)
 	if err!=nil {
 		return nil, fmt.Errorf("cannot create compiler artifact: %w", err)
@@ -45,6 +81,16 @@ func createToolchain(toolchain *modcfg.Toolchain) (*Toolchain, error) {
 		return nil, fmt.Errorf("cannot create linker artifact: %w", err)
 	}
 
+	interpreter, err := createArtifact(toolchain.Interpreter)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot create interpreter artifact: %w", err)
+	}
+
+	archiver, err := createArtifact(toolchain.Archiver)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot create archiver artifact: %w", err)
+	}
+
 	stdlib, err := createArtifact(toolchain.Stdlib)
 	if err!=nil {
 		return nil, fmt.Errorf("cannot create stdlib artifact: %w", err)
@@ -72,13 +118,29 @@ func createToolchain(toolchain *modcfg.Toolchain) (*Toolchain, error) {
 		}
 		startfiles = append(startfiles, *artifact)
 	}
+
+	sharedStartfiles := []Artifact{}
+	for _, lib := range toolchain.SharedStartfiles {
+		artifact, err := createArtifact(lib)
+		if err!=nil {
+			return nil, fmt.Errorf("cannot create shared startfiles artifact: %w", err)
+		}
+		sharedStartfiles = append(sharedStartfiles, *artifact)
+	}
 	
 	return &Toolchain{
+		Name: toolchain.Name,
+		Flavor: flavor,
+		Target: toolchain.Target,
+		Sysroot: *sysroot,
 		Compiler: *compiler,
 		Linker: *linker,
+		Interpreter: *interpreter,
+		Archiver: *archiver,
 		Stdlib: *stdlib,
 		Stdpplib: *stdpplib,
 		Supportlibs: supportlibs,
 		Startfiles: startfiles,
+		SharedStartfiles: sharedStartfiles,
 	}, nil
 }
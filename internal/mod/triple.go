@@ -0,0 +1,144 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mod
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ARCHS maps legacy architecture names and common aliases to the architecture of a target triple.
+var ARCHS = map[string]string{
+	"amd64": "x86_64",
+	"x86_64": "x86_64",
+	"arm64": "aarch64",
+	"aarch64": "aarch64",
+	"386": "i686",
+	"i686": "i686",
+	"arm": "arm",
+	"riscv64": "riscv64",
+}
+
+// PLATFORMS maps legacy platform names to the operating systems they cover.
+// The platform 'unix' is a family covering all unix-like systems, while 'linux' only covers linux.
+var PLATFORMS = map[string][]string{
+	"linux": {"linux"},
+	"darwin": {"darwin"},
+	"freebsd": {"freebsd"},
+	"windows": {"windows"},
+	"unix": {"linux", "darwin", "freebsd"},
+}
+
+// Triple describes a compilation target in the form arch-vendor-os-abi (e.g. 'x86_64-unknown-linux-gnu').
+// Vendor and abi are optional and may be empty.
+type Triple struct {
+	Arch string
+	Vendor string
+	OS string
+	ABI string
+}
+
+// ParseTriple parses a target triple. The vendor may be omitted (e.g. 'aarch64-linux-gnu', 'x86_64-linux-musl')
+// and architecture aliases are normalized (e.g. 'amd64' becomes 'x86_64').
+func ParseTriple(triple string) (*Triple, error) {
+	parts := strings.Split(triple, "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		return nil, fmt.Errorf("invalid target triple '%s'; expected 'arch-vendor-os-abi'", triple)
+	}
+
+	arch, ok := ARCHS[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown architecture '%s' in target triple '%s'", parts[0], triple)
+	}
+
+	output := &Triple{Arch: arch}
+	switch len(parts) {
+	case 2:
+		output.OS = parts[1]
+	case 3:
+		if isOS(parts[1]) {
+			output.OS, output.ABI = parts[1], parts[2]
+		} else {
+			output.Vendor, output.OS = parts[1], parts[2]
+		}
+	case 4:
+		output.Vendor, output.OS, output.ABI = parts[1], parts[2], parts[3]
+	}
+
+	// versioned systems (e.g. 'darwin23') are matched by their name, mingw is the gnu abi of windows.
+	output.OS = strings.TrimRight(output.OS, "0123456789.")
+	if output.OS == "mingw" {
+		output.OS, output.ABI = "windows", "gnu"
+	}
+	if !isOS(output.OS) {
+		return nil, fmt.Errorf("unknown operating system '%s' in target triple '%s'", output.OS, triple)
+	}
+	return output, nil
+}
+
+// PlatformTriple creates the target triple of a legacy platform / arch pair. Platform families like 'unix'
+// cannot be built for, as they do not describe a single operating system.
+func PlatformTriple(platform, arch string) (*Triple, error) {
+	oses, ok := PLATFORMS[platform]
+	if !ok {
+		return nil, fmt.Errorf("unknown platform '%s'", platform)
+	}
+	if len(oses) != 1 {
+		return nil, fmt.Errorf("platform '%s' is a family of '%v'; specify one of them", platform, oses)
+	}
+
+	tripleArch, ok := ARCHS[arch]
+	if !ok {
+		return nil, fmt.Errorf("unknown architecture '%s'", arch)
+	}
+	return &Triple{Arch: tripleArch, OS: oses[0]}, nil
+}
+
+// Matches checks if the triple can build for the target. The vendor is not significant and the abi is only
+// compared if both triples specify one.
+func (t *Triple) Matches(target *Triple) bool {
+	if t.Arch != target.Arch || t.OS != target.OS {
+		return false
+	}
+	return t.ABI == "" || target.ABI == "" || t.ABI == target.ABI
+}
+
+func (t *Triple) String() string {
+	parts := []string{t.Arch}
+	if t.Vendor != "" {
+		parts = append(parts, t.Vendor)
+	}
+	parts = append(parts, t.OS)
+	if t.ABI != "" {
+		parts = append(parts, t.ABI)
+	}
+	return strings.Join(parts, "-")
+}
+
+// isOS checks if the name is a known operating system.
+func isOS(name string) bool {
+	for _, oses := range PLATFORMS {
+		if slices.Contains(oses, name) {
+			return true
+		}
+	}
+	return name == "mingw"
+}
@@ -0,0 +1,194 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mvs
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version in the form 'v<major>.<minor>.<patch>[-<prerelease>]'.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Prerelease string
+}
+
+// Parse parses a semantic version. The 'v' prefix is required, build metadata ('+...') is ignored.
+func Parse(raw string) (*Version, error) {
+	core, ok := strings.CutPrefix(raw, "v")
+	if !ok {
+		return nil, fmt.Errorf("version '%s' must start with 'v'", raw)
+	}
+	core, _, _ = strings.Cut(core, "+")
+	core, prerelease, _ := strings.Cut(core, "-")
+
+	segments := strings.Split(core, ".")
+	if len(segments) != 3 {
+		return nil, fmt.Errorf("version '%s' must have the form 'v<major>.<minor>.<patch>'", raw)
+	}
+	numbers := [3]int{}
+	for i, segment := range segments {
+		number, err := strconv.Atoi(segment)
+		if err!=nil || number < 0 {
+			return nil, fmt.Errorf("version '%s' contains invalid number '%s'", raw, segment)
+		}
+		numbers[i] = number
+	}
+
+	return &Version{
+		Major: numbers[0],
+		Minor: numbers[1],
+		Patch: numbers[2],
+		Prerelease: prerelease,
+	}, nil
+}
+
+// Compare returns -1 if a is lower than b, 1 if a is higher than b and 0 if both are equal.
+// Prereleases are lower than the release and compared by their identifiers among each other.
+func Compare(a, b *Version) int {
+	for _, diff := range []int{a.Major - b.Major, a.Minor - b.Minor, a.Patch - b.Patch} {
+		if diff < 0 {
+			return -1
+		} else if diff > 0 {
+			return 1
+		}
+	}
+	switch {
+	case a.Prerelease == b.Prerelease:
+		return 0
+	case a.Prerelease == "":
+		return 1
+	case b.Prerelease == "":
+		return -1
+	}
+	return comparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+// comparePrerelease compares the dot separated prerelease identifiers from left to right. Numeric identifiers
+// are compared numerically and are lower than alphanumeric identifiers, which are compared lexically.
+// If all identifiers are equal, the prerelease with fewer identifiers is lower.
+func comparePrerelease(a, b string) int {
+	aIdents, bIdents := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIdents) && i < len(bIdents); i++ {
+		aNumber, aErr := strconv.ParseUint(aIdents[i], 10, 64)
+		bNumber, bErr := strconv.ParseUint(bIdents[i], 10, 64)
+		switch {
+		case aErr==nil && bErr==nil:
+			if aNumber != bNumber {
+				if aNumber < bNumber {
+					return -1
+				}
+				return 1
+			}
+		case aErr==nil:
+			return -1
+		case bErr==nil:
+			return 1
+		default:
+			if diff := strings.Compare(aIdents[i], bIdents[i]); diff != 0 {
+				return diff
+			}
+		}
+	}
+	switch {
+	case len(aIdents) < len(bIdents):
+		return -1
+	case len(aIdents) > len(bIdents):
+		return 1
+	}
+	return 0
+}
+
+// Requirement states that a module requires a specific revision of another module.
+type Requirement struct {
+	Module string
+	Revision string
+	RequiredBy string
+}
+
+// Selection is the revision selected for a module together with all requirements that were considered.
+type Selection struct {
+	Module string
+	Revision string
+	Requirements []Requirement
+}
+
+// Select performs a minimal version selection: every module is used in the highest revision any module in the
+// requirement graph requires. The requirements must contain the requirements of every reachable module revision.
+// Modules required in multiple revisions must use semantic versions, otherwise no revision can be selected.
+func Select(requirements []Requirement) (map[string]*Selection, error) {
+	selections := map[string]*Selection{}
+	for _, requirement := range requirements {
+		selection, ok := selections[requirement.Module]
+		if !ok {
+			selection = &Selection{Module: requirement.Module, Revision: requirement.Revision}
+			selections[requirement.Module] = selection
+		}
+		selection.Requirements = append(selection.Requirements, requirement)
+	}
+
+	for _, selection := range selections {
+		if len(selection.Revisions()) < 2 {
+			continue
+		}
+		var selected *Version
+		for _, requirement := range selection.Requirements {
+			version, err := Parse(requirement.Revision)
+			if err!=nil {
+				return nil, fmt.Errorf(
+					"cannot select a revision of module '%s' required by '%s': %w",
+					selection.Module, requirement.RequiredBy, err,
+				)
+			}
+			if selected == nil || Compare(version, selected) > 0 {
+				selected = version
+				selection.Revision = requirement.Revision
+			}
+		}
+	}
+	return selections, nil
+}
+
+// Revisions returns all distinct revisions that are required.
+func (s *Selection) Revisions() []string {
+	revisions := []string{}
+	for _, requirement := range s.Requirements {
+		if !slices.Contains(revisions, requirement.Revision) {
+			revisions = append(revisions, requirement.Revision)
+		}
+	}
+	return revisions
+}
+
+// Explain describes why the revision was selected.
+func (s *Selection) Explain() string {
+	requirements := []string{}
+	for _, requirement := range s.Requirements {
+		requirements = append(requirements, fmt.Sprintf("'%s' requires '%s'", requirement.RequiredBy, requirement.Revision))
+	}
+	return fmt.Sprintf(
+		"selected revision '%s' of module '%s' as it is the highest required revision (%s)",
+		s.Revision, s.Module, strings.Join(requirements, ", "),
+	)
+}
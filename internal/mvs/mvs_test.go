@@ -0,0 +1,160 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mvs
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCompare compares pairs of versions in both directions.
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a string
+		b string
+		want int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"v1.0.0", "v1.0.1", -1},
+		{"v1.0.0", "v1.1.0", -1},
+		{"v1.0.0", "v2.0.0", -1},
+		{"v1.9.0", "v1.10.0", -1},
+		{"v1.0.10", "v1.0.9", 1},
+		{"v1.0.0+build.1", "v1.0.0+build.2", 0},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-rc.2", "v1.0.0-rc.10", -1},
+		{"v1.0.0-alpha", "v1.0.0-alpha.1", -1},
+		{"v1.0.0-alpha.1", "v1.0.0-alpha.beta", -1},
+		{"v1.0.0-alpha.beta", "v1.0.0-beta", -1},
+		{"v1.0.0-beta", "v1.0.0-beta.2", -1},
+		{"v1.0.0-beta.2", "v1.0.0-beta.11", -1},
+		{"v1.0.0-beta.11", "v1.0.0-rc.1", -1},
+		{"v1.0.0-1", "v1.0.0-a", -1},
+		{"v1.0.0-rc.1", "v0.9.0", 1},
+	}
+	for _, test := range tests {
+		a, err := Parse(test.a)
+		if err!=nil {
+			t.Fatalf("failed to parse '%s': %v", test.a, err)
+		}
+		b, err := Parse(test.b)
+		if err!=nil {
+			t.Fatalf("failed to parse '%s': %v", test.b, err)
+		}
+		if got := Compare(a, b); got != test.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", test.a, test.b, got, test.want)
+		}
+		if got := Compare(b, a); got != -test.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", test.b, test.a, got, -test.want)
+		}
+	}
+}
+
+// TestParseInvalid ensures that malformed versions are rejected.
+func TestParseInvalid(t *testing.T) {
+	for _, raw := range []string{"1.0.0", "v1.0", "v1.0.0.0", "v1.x.0", "v-1.0.0", "main"} {
+		if _, err := Parse(raw); err==nil {
+			t.Errorf("expected '%s' to be rejected", raw)
+		}
+	}
+}
+
+// TestSelect selects revisions from sets of requirements.
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name string
+		requirements []Requirement
+		want map[string]string
+		err string
+	}{
+		{
+			name: "single revision",
+			requirements: []Requirement{
+				{Module: "example.com/a", Revision: "main", RequiredBy: "example.com/root"},
+			},
+			want: map[string]string{"example.com/a": "main"},
+		},
+		{
+			name: "highest revision wins",
+			requirements: []Requirement{
+				{Module: "example.com/a", Revision: "v1.2.0", RequiredBy: "example.com/root"},
+				{Module: "example.com/a", Revision: "v1.10.0", RequiredBy: "example.com/b@v1.0.0"},
+				{Module: "example.com/a", Revision: "v1.9.0", RequiredBy: "example.com/c@v1.0.0"},
+				{Module: "example.com/b", Revision: "v1.0.0", RequiredBy: "example.com/root"},
+				{Module: "example.com/c", Revision: "v1.0.0", RequiredBy: "example.com/root"},
+			},
+			want: map[string]string{
+				"example.com/a": "v1.10.0",
+				"example.com/b": "v1.0.0",
+				"example.com/c": "v1.0.0",
+			},
+		},
+		{
+			name: "release wins over prerelease",
+			requirements: []Requirement{
+				{Module: "example.com/a", Revision: "v1.0.0", RequiredBy: "example.com/root"},
+				{Module: "example.com/a", Revision: "v1.0.0-rc.1", RequiredBy: "example.com/b@v1.0.0"},
+			},
+			want: map[string]string{"example.com/a": "v1.0.0"},
+		},
+		{
+			name: "same non semantic revision",
+			requirements: []Requirement{
+				{Module: "example.com/a", Revision: "main", RequiredBy: "example.com/root"},
+				{Module: "example.com/a", Revision: "main", RequiredBy: "example.com/b@v1.0.0"},
+			},
+			want: map[string]string{"example.com/a": "main"},
+		},
+		{
+			name: "conflicting non semantic revision",
+			requirements: []Requirement{
+				{Module: "example.com/a", Revision: "v1.0.0", RequiredBy: "example.com/root"},
+				{Module: "example.com/a", Revision: "main", RequiredBy: "example.com/b@v1.0.0"},
+			},
+			err: "cannot select a revision of module 'example.com/a' required by 'example.com/b@v1.0.0'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selections, err := Select(test.requirements)
+			if test.err != "" {
+				if err==nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing '%s' got '%v'", test.err, err)
+				}
+				return
+			}
+			if err!=nil {
+				t.Fatal(err)
+			}
+			if len(selections) != len(test.want) {
+				t.Fatalf("expected '%d' selections got '%d'", len(test.want), len(selections))
+			}
+			for module, revision := range test.want {
+				selection, ok := selections[module]
+				if !ok {
+					t.Fatalf("module '%s' was not selected", module)
+				}
+				if selection.Revision != revision {
+					t.Errorf("expected revision '%s' of module '%s' got '%s'", revision, module, selection.Revision)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mvs
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Include is an include declared by a module revision. The base is the source without the revision, all
+// includes of a module must share the same base.
+type Include struct {
+	Module string
+	Source string
+	Base string
+	Revision string
+}
+
+// Resolve walks all module revisions reachable from the root and selects a revision for every module. The
+// includes of a revision are listed by $includes and every required revision is loaded once with $load, $id
+// identifies a revision in requirements and errors. Includes of the root module itself are skipped.
+// Returns the selected revision of every module (including the root) and the selections that lead to them.
+func Resolve[T any](
+	root T, rootModule string,
+	id func(T) string,
+	includes func(T) []Include,
+	load func(parent T, include Include) (T, error),
+) (map[string]T, map[string]*Selection, error) {
+	first := map[string]Requirement{}
+	sources := map[string]Include{}
+	revisions := map[string]map[string]T{}
+	requirements := []Requirement{}
+
+	queue := []T{root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, include := range includes(current) {
+			if include.Module == rootModule {
+				slog.Debug(fmt.Sprintf(
+					"module '%s' includes the root module '%s'; skipping include...", id(current), include.Module,
+				))
+				continue
+			}
+
+			requirement := Requirement{Module: include.Module, Revision: include.Revision, RequiredBy: id(current)}
+			if existing, ok := sources[include.Module]; !ok {
+				sources[include.Module] = include
+				first[include.Module] = requirement
+			} else if existing.Base != include.Base {
+				return nil, nil, fmt.Errorf(
+					"module '%s' is included with conflicting sources '%s' (by '%s') and '%s' (by '%s')",
+					include.Module, existing.Source, first[include.Module].RequiredBy, include.Source, id(current),
+				)
+			}
+			requirements = append(requirements, requirement)
+
+			if _, ok := revisions[include.Module][include.Revision]; ok {
+				continue
+			}
+			included, err := load(current, include)
+			if err!=nil {
+				return nil, nil, fmt.Errorf("failed to load include '%s': %w", include.Module, err)
+			}
+			if _, ok := revisions[include.Module]; !ok {
+				revisions[include.Module] = map[string]T{}
+			}
+			revisions[include.Module][include.Revision] = included
+			queue = append(queue, included)
+		}
+	}
+
+	selections, err := Select(requirements)
+	if err!=nil {
+		return nil, nil, err
+	}
+
+	modules := map[string]T{rootModule: root}
+	for modulePath, selection := range selections {
+		modules[modulePath] = revisions[modulePath][selection.Revision]
+	}
+	return modules, selections, nil
+}
@@ -0,0 +1,158 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/megakuul/bob/internal/graph"
+	"github.com/megakuul/bob/internal/mod"
+)
+
+// build contains the resolved (and after compilation the compiled) dependency graph of a root pack.
+type build struct {
+	graph *graph.Graph
+	chain *toolchain
+	units map[string]*unit
+	externals map[string]*external
+	objects []string
+	libraries []*external
+	rpaths []string
+	linkerFlags []string
+}
+
+// buildGraph resolves the dependency graph of the root pack and compiles all its packs.
+func (p *Processor) buildGraph(module *mod.Mod, modPath string, root string, rootChain *mod.Toolchain, pic bool) (*build, error) {
+	b, err := p.resolveGraph(module, modPath, root, rootChain, pic)
+	if err!=nil {
+		return nil, err
+	}
+
+	b.objects, err = p.compileGraph(b.graph, b.units, b.externals)
+	if err!=nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// resolveGraph loads all modules, resolves the dependency graph of the root pack and loads the toolchains and
+// externals of all its packs. Packs use the root toolchain unless they are part of an include with remote toolchain.
+func (p *Processor) resolveGraph(module *mod.Mod, modPath string, root string, rootChain *mod.Toolchain, pic bool) (*build, error) {
+	modules, err := p.loadModules(module, modPath)
+	if err!=nil {
+		return nil, fmt.Errorf("failed to load modules: %w", err)
+	}
+
+	chain, err := p.loadToolchain(rootChain)
+	if err!=nil {
+		return nil, fmt.Errorf("failed to load toolchain: %w", err)
+	}
+	toolchains := map[string]*toolchain{
+		toolchainKey("", rootChain.Name): chain,
+	}
+
+	units := map[string]*unit{}
+	depGraph, err := graph.Build(root, func(path string) ([]string, error) {
+		u, err := p.loadPack(modules, path)
+		if err!=nil {
+			return nil, err
+		}
+
+		chainModule, packChain, err := selectToolchain(u.owner, path, rootChain)
+		if err!=nil {
+			return nil, err
+		}
+		chainKey := toolchainKey(chainModule, packChain.Name)
+		if _, ok := toolchains[chainKey]; !ok {
+			toolchains[chainKey], err = p.loadToolchain(packChain)
+			if err!=nil {
+				return nil, fmt.Errorf("failed to load toolchain '%s' of module '%s': %w", packChain.Name, chainModule, err)
+			}
+		}
+		u.chain = toolchains[chainKey]
+		u.pic = pic
+
+		units[path] = u
+		return u.cfg.Deps, nil
+	})
+	if err!=nil {
+		return nil, fmt.Errorf("failed to resolve dependencies: %w", err)
+	}
+
+	externals := map[string]*external{}
+	libraries := []*external{}
+	rpaths := []string{}
+	for _, node := range depGraph.Order() {
+		if node.Type != graph.NODE_EXTERNAL {
+			continue
+		}
+		ext, err := p.loadExternal(modules, node.Path)
+		if err!=nil {
+			return nil, fmt.Errorf("failed to load external: %w", err)
+		}
+		externals[node.Path] = ext
+		libraries = append(libraries, ext)
+		for _, rpath := range ext.rpaths {
+			if !slices.Contains(rpaths, rpath) {
+				rpaths = append(rpaths, rpath)
+			}
+		}
+	}
+
+	return &build{
+		graph: depGraph,
+		chain: chain,
+		units: units,
+		externals: externals,
+		objects: []string{},
+		libraries: libraries,
+		rpaths: rpaths,
+		linkerFlags: collectUsage(depGraph, depGraph.Root, units, externals).linkerFlags,
+	}, nil
+}
+
+// collectUsage collects the settings of the pack together with the public settings of all packs and the headers
+// of all externals it transitively depends on. Flags of dependencies precede the flags of the pack, so that the
+// pack can override them.
+func collectUsage(depGraph *graph.Graph, node *graph.Node, units map[string]*unit, externals map[string]*external) *usage {
+	u := units[node.Path]
+	collected := &usage{
+		includeDirs: union(u.public.includeDirs, u.private.includeDirs),
+		defines: union(u.public.defines, u.private.defines),
+	}
+
+	compilerFlags, linkerFlags := []string{}, []string{}
+	for _, dep := range depGraph.Closure(node) {
+		switch dep.Type {
+		case graph.NODE_PACK:
+			public := units[dep.Path].public
+			collected.includeDirs = union(collected.includeDirs, public.includeDirs)
+			collected.defines = union(collected.defines, public.defines)
+			compilerFlags = append(compilerFlags, public.compilerFlags...)
+			linkerFlags = append(linkerFlags, public.linkerFlags...)
+		case graph.NODE_EXTERNAL:
+			collected.includeDirs = union(collected.includeDirs, externals[dep.Path].includeDirs)
+		}
+	}
+	collected.compilerFlags = slices.Concat(compilerFlags, u.public.compilerFlags, u.private.compilerFlags)
+	collected.linkerFlags = slices.Concat(linkerFlags, u.public.linkerFlags, u.private.linkerFlags)
+	return collected
+}
@@ -0,0 +1,126 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/megakuul/bob/internal/loader"
+)
+
+// record describes the inputs of a compiled translation unit. It is stored next to the object
+// and used to decide whether the translation unit must be recompiled.
+type record struct {
+	Toolchain string `toml:"toolchain"`
+	Args []string `toml:"args"`
+	Source string `toml:"source"`
+	Headers map[string]string `toml:"headers"`
+}
+
+// loadRecord reads the record of the object. Missing or corrupt records result in a nil record.
+func loadRecord(object string) *record {
+	rawRecord, err := os.ReadFile(object+".rec")
+	if err!=nil {
+		return nil
+	}
+	rec := &record{}
+	if _, err := toml.Decode(string(rawRecord), rec); err!=nil {
+		return nil
+	}
+	return rec
+}
+
+// writeRecord creates the record of the object by hashing the source and all headers listed in the depfile.
+func writeRecord(object, depfile, identity string, args []string, source string) error {
+	sourceHash, err := loader.HashFile(source)
+	if err!=nil {
+		return err
+	}
+
+	rawDeps, err := os.ReadFile(depfile)
+	if err!=nil {
+		return fmt.Errorf("cannot read depfile: %w", err)
+	}
+	headers := map[string]string{}
+	for _, header := range parseDepfile(string(rawDeps)) {
+		if header == source {
+			continue
+		}
+		hash, err := loader.HashFile(header)
+		if err!=nil {
+			return err
+		}
+		headers[header] = hash
+	}
+
+	recordFile, err := os.Create(object+".rec")
+	if err!=nil {
+		return err
+	}
+	defer recordFile.Close()
+
+	return toml.NewEncoder(recordFile).Encode(&record{
+		Toolchain: identity,
+		Args: args,
+		Source: sourceHash,
+		Headers: headers,
+	})
+}
+
+// upToDate checks whether the object exists and none of the recorded inputs changed.
+func upToDate(object, identity string, args []string, source string) bool {
+	if _, err := os.Stat(object); err!=nil {
+		return false
+	}
+	rec := loadRecord(object)
+	if rec == nil || rec.Toolchain != identity || !slices.Equal(rec.Args, args) {
+		return false
+	}
+	if hash, err := loader.HashFile(source); err!=nil || hash != rec.Source {
+		return false
+	}
+	for header, recHash := range rec.Headers {
+		if hash, err := loader.HashFile(header); err!=nil || hash != recHash {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDepfile extracts the prerequisites of a make rule generated by the compiler with '-MMD'.
+func parseDepfile(content string) []string {
+	content = strings.ReplaceAll(content, "\\\r\n", " ")
+	content = strings.ReplaceAll(content, "\\\n", " ")
+	_, prerequisites, ok := strings.Cut(content, ": ")
+	if !ok {
+		return []string{}
+	}
+
+	deps := []string{}
+	// escaped spaces are part of the path and must not split it.
+	for _, dep := range strings.Fields(strings.ReplaceAll(prerequisites, "\\ ", "\x00")) {
+		deps = append(deps, strings.ReplaceAll(dep, "\x00", " "))
+	}
+	return deps
+}
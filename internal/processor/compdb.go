@@ -0,0 +1,76 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/megakuul/bob/internal/graph"
+	"github.com/megakuul/bob/internal/mod"
+)
+
+// CompileCommand describes the compilation of a translation unit in the format of a clang compilation database.
+type CompileCommand struct {
+	Directory string `json:"directory"`
+	File string `json:"file"`
+	Arguments []string `json:"arguments"`
+	Output string `json:"output"`
+}
+
+// CompileCommands resolves the dependency graph of the target and returns the compile commands of all sources
+// and tests of its packs, exactly as they are executed when the target is built. Nothing is compiled, but
+// toolchains and externals are loaded, so that the commands reference their location in the cache.
+func (p *Processor) CompileCommands(module *mod.Mod, modPath string, target string) ([]*CompileCommand, error) {
+	modTarget, ok := module.Targets[target]
+	if !ok {
+		return nil, fmt.Errorf("target '%s' is not defined in module '%s'", target, module.Module)
+	}
+
+	pic := modTarget.Library && modTarget.Linkage != mod.LINKAGE_STATIC
+	b, err := p.resolveGraph(module, modPath, target, modTarget.Toolchain, pic)
+	if err!=nil {
+		return nil, err
+	}
+
+	commands := []*CompileCommand{}
+	for _, node := range b.graph.Order() {
+		if node.Type != graph.NODE_PACK {
+			continue
+		}
+		u := b.units[node.Path]
+		use := collectUsage(b.graph, node, b.units, b.externals)
+		for _, source := range slices.Concat(u.sources, u.tests) {
+			rel, err := filepath.Rel(u.dir, source)
+			if err!=nil {
+				return nil, err
+			}
+			object := p.objectPath(u, rel)
+			commands = append(commands, &CompileCommand{
+				Directory: u.dir,
+				File: source,
+				Arguments: append([]string{u.chain.compiler}, p.unitArgs(u, use, source, object)...),
+				Output: object,
+			})
+		}
+	}
+	return commands, nil
+}
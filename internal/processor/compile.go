@@ -0,0 +1,132 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// PIC_DIR separates position independent objects from regular objects in the cache.
+const PIC_DIR = "pic"
+
+// compilePack compiles every source of the pack into a separate object file and returns the object paths.
+// The usage must contain the settings of the pack and the public settings of all its dependencies.
+// Every translation unit occupies one of the workers while it is compiled.
+// Objects are placed at $cachePath/obj/$profile/$pack/$source.o.
+func (p *Processor) compilePack(ctx context.Context, workers chan struct{}, u *unit, use *usage) ([]string, error) {
+	objects := make([]string, len(u.sources))
+	errs := make([]error, len(u.sources))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, source := range u.sources {
+		group.Go(func() error {
+			objects[i], errs[i] = p.compileUnit(groupCtx, workers, u, use, source)
+			if !p.keepGoing {
+				return errs[i]
+			}
+			return nil
+		})
+	}
+	if err:=group.Wait(); err!=nil {
+		return nil, err
+	}
+	if err:=errors.Join(errs...); err!=nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// compileUnit compiles a single source of the pack and returns the path of the object. The compilation is
+// skipped if the source, its headers, the arguments and the toolchain did not change since the last compilation.
+func (p *Processor) compileUnit(ctx context.Context, workers chan struct{}, u *unit, use *usage, source string) (string, error) {
+	rel, err := filepath.Rel(u.dir, source)
+	if err!=nil {
+		return "", err
+	}
+	object := p.objectPath(u, rel)
+	if err:=os.MkdirAll(filepath.Dir(object), 0755); err!=nil {
+		return "", err
+	}
+
+	depfile := object+".d"
+	args := p.unitArgs(u, use, source, object)
+	if upToDate(object, u.chain.identity, args, source) {
+		slog.Debug(fmt.Sprintf("'%s' is up to date; skipping compilation...", rel))
+		return object, nil
+	}
+
+	release, err := acquire(ctx, workers)
+	if err!=nil {
+		return "", err
+	}
+	defer release()
+
+	err = p.execute(ctx, u.chain.compiler, args...)
+	if err!=nil {
+		return "", fmt.Errorf("failed to compile '%s': %w", rel, err)
+	}
+
+	err = writeRecord(object, depfile, u.chain.identity, args, source)
+	if err!=nil {
+		return "", fmt.Errorf("failed to record '%s': %w", rel, err)
+	}
+	return object, nil
+}
+
+// objectPath returns the path of the object compiled from the source located at $rel in the pack directory.
+// Position independent objects are placed at $cachePath/obj/$profile/pic/$pack, so that both variants are cached.
+func (p *Processor) objectPath(u *unit, rel string) string {
+	if u.pic {
+		return filepath.Join(p.outputPath("obj", path.Join(PIC_DIR, u.path)), rel+".o")
+	}
+	return filepath.Join(p.outputPath("obj", u.path), rel+".o")
+}
+
+// unitArgs assembles the compiler arguments of a translation unit including the depfile used to track its headers.
+func (p *Processor) unitArgs(u *unit, use *usage, source, object string) []string {
+	return append(p.compileArgs(u, use, source, object), "-MMD", "-MF", object+".d")
+}
+
+// compileArgs assembles the compiler arguments used to compile the source into the object.
+// Flags of the pack follow the flags of the profile, so that packs can override them.
+func (p *Processor) compileArgs(u *unit, use *usage, source, object string) []string {
+	args := append(flavorArgs(u.chain), sysrootArgs(u.chain)...)
+	if u.cfg.Std != "" {
+		args = append(args, fmt.Sprintf("-std=c++%s", u.cfg.Std))
+	}
+	for _, dir := range use.includeDirs {
+		args = append(args, "-I", dir)
+	}
+	args = append(args, use.defines...)
+	if u.pic {
+		args = append(args, "-fPIC")
+	}
+	args = append(args, p.profile.CompilerFlags...)
+	args = append(args, use.compilerFlags...)
+	return append(args, "-c", source, "-o", object)
+}
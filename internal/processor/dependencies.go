@@ -0,0 +1,87 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"fmt"
+
+	"github.com/megakuul/bob/internal/graph"
+	"github.com/megakuul/bob/internal/mod"
+)
+
+// Dependencies describes the dependency graph of a target. Modules maps every node to the module (and revision)
+// declaring it, Toolchains maps every pack to the toolchain it is compiled with ('<module>/@toolchain:<name>').
+type Dependencies struct {
+	Graph *graph.Graph
+	Modules map[string]string
+	Toolchains map[string]string
+}
+
+// ResolveDependencies resolves the dependency graph of the target without loading toolchains or compiling packs.
+// Included modules are still fetched, as their packs are part of the graph.
+func (p *Processor) ResolveDependencies(module *mod.Mod, modPath string, target string) (*Dependencies, error) {
+	modTarget, ok := module.Targets[target]
+	if !ok {
+		return nil, fmt.Errorf("target '%s' is not defined in module '%s'", target, module.Module)
+	}
+
+	modules, err := p.loadModules(module, modPath)
+	if err!=nil {
+		return nil, fmt.Errorf("failed to load modules: %w", err)
+	}
+
+	deps := &Dependencies{
+		Graph: nil,
+		Modules: map[string]string{},
+		Toolchains: map[string]string{},
+	}
+	deps.Graph, err = graph.Build(target, func(path string) ([]string, error) {
+		u, err := p.loadPack(modules, path)
+		if err!=nil {
+			return nil, err
+		}
+		chainModule, chain, err := selectToolchain(u.owner, path, modTarget.Toolchain)
+		if err!=nil {
+			return nil, err
+		}
+		if chainModule == "" {
+			chainModule = module.Module
+		}
+		deps.Modules[path] = u.owner.id()
+		deps.Toolchains[path] = fmt.Sprintf("%s/@toolchain:%s", chainModule, chain.Name)
+		return u.cfg.Deps, nil
+	})
+	if err!=nil {
+		return nil, fmt.Errorf("failed to resolve dependencies: %w", err)
+	}
+
+	for _, node := range deps.Graph.Order() {
+		if node.Type != graph.NODE_EXTERNAL {
+			continue
+		}
+		modulePath, _, _ := graph.ParseExternal(node.Path)
+		if owner, ok := modules[modulePath]; ok {
+			deps.Modules[node.Path] = owner.id()
+		} else {
+			deps.Modules[node.Path] = modulePath
+		}
+	}
+	return deps, nil
+}
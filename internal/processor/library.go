@@ -0,0 +1,107 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/megakuul/bob/internal/mod"
+	"golang.org/x/sync/errgroup"
+)
+
+// buildLibrary creates the static archive and / or the shared object of the library target and installs the
+// headers matched by the includes of the target pack to $output/include. Both contain the objects of the pack
+// and of all packs it depends on, so that consumers only need to link the library itself (and its externals).
+func (p *Processor) buildLibrary(chain *toolchain, target *mod.Target, u *unit, flags []string, objects []string, externals []*external, output string) error {
+	name := fmt.Sprintf("lib%s", filepath.Base(u.path))
+
+	group := errgroup.Group{}
+	if target.Linkage == mod.LINKAGE_STATIC || target.Linkage == mod.LINKAGE_BOTH {
+		group.Go(func() error {
+			if err:=p.archive(chain, objects, filepath.Join(output, name+".a")); err!=nil {
+				return fmt.Errorf("failed to archive library: %w", err)
+			}
+			return nil
+		})
+	}
+	if target.Linkage == mod.LINKAGE_SHARED || target.Linkage == mod.LINKAGE_BOTH {
+		group.Go(func() error {
+			err := p.linkShared(chain, flags, objects, externals, filepath.Join(output, name+".so"), name+".so")
+			if err!=nil {
+				return fmt.Errorf("failed to link shared library: %w", err)
+			}
+			return nil
+		})
+	}
+	if err:=group.Wait(); err!=nil {
+		return err
+	}
+
+	if err:=installHeaders(u, filepath.Join(output, "include")); err!=nil {
+		return fmt.Errorf("failed to install headers: %w", err)
+	}
+	return nil
+}
+
+// installHeaders copies the headers of the pack to the include directory while preserving their relative path.
+func installHeaders(u *unit, includeDir string) error {
+	if err:=os.RemoveAll(includeDir); err!=nil {
+		return err
+	}
+	for _, header := range u.includes {
+		rel, err := filepath.Rel(u.dir, header)
+		if err!=nil {
+			return err
+		}
+		if err:=copyFile(header, filepath.Join(includeDir, rel)); err!=nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile copies the file content and permissions from $src to $dst, creating missing parent directories.
+func copyFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err!=nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	srcInfo, err := srcFile.Stat()
+	if err!=nil {
+		return err
+	}
+
+	if err:=os.MkdirAll(filepath.Dir(dst), 0755); err!=nil {
+		return err
+	}
+	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, srcInfo.Mode().Perm())
+	if err!=nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	_, err = io.Copy(dstFile, srcFile)
+	return err
+}
@@ -0,0 +1,118 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// link links the objects with the startfiles and libraries of the toolchain and externals into an executable.
+// The linker flags are added after the flags of the profile.
+func (p *Processor) link(chain *toolchain, flags []string, objects []string, externals []*external, output string) error {
+	if err:=os.MkdirAll(filepath.Dir(output), 0755); err!=nil {
+		return err
+	}
+	flags = slices.Concat(p.profile.LinkerFlags, flags)
+	return p.execute(p.ctx, chain.linker, linkArgs(chain, flags, objects, externals, output)...)
+}
+
+// linkShared links the objects with the shared startfiles and libraries into a shared object named $soname.
+func (p *Processor) linkShared(chain *toolchain, flags []string, objects []string, externals []*external, output, soname string) error {
+	if err:=os.MkdirAll(filepath.Dir(output), 0755); err!=nil {
+		return err
+	}
+	flags = slices.Concat(p.profile.LinkerFlags, flags)
+	return p.execute(p.ctx, chain.linker, linkSharedArgs(chain, flags, objects, externals, output, soname)...)
+}
+
+// archive bundles the objects into a static archive with the archiver of the toolchain.
+func (p *Processor) archive(chain *toolchain, objects []string, output string) error {
+	if chain.archiver == "" {
+		return fmt.Errorf("toolchain does not specify an archiver")
+	}
+	if err:=os.MkdirAll(filepath.Dir(output), 0755); err!=nil {
+		return err
+	}
+	// the archiver replaces members in existing archives, stale members of removed sources would remain.
+	if err:=os.RemoveAll(output); err!=nil {
+		return err
+	}
+	return p.execute(p.ctx, chain.archiver, append([]string{"rcs", output}, objects...)...)
+}
+
+// linkArgs assembles the linker arguments. The order is significant: startfiles (crt1.o, crti.o, crtbegin.o)
+// must precede the objects, libraries must follow the objects that reference them and the terminating
+// startfiles (crtend.o, crtn.o) come last.
+func linkArgs(chain *toolchain, flags []string, objects []string, externals []*external, output string) []string {
+	args := append(linkerArgs(chain), "-o", output)
+	if chain.interpreter != "" {
+		args = append(args, "-dynamic-linker", runtimePath(chain, chain.interpreter))
+	}
+	args = append(args, flags...)
+
+	prologue, epilogue := splitStartfiles(chain.startfiles)
+	args = append(args, prologue...)
+	args = append(args, objects...)
+	args = append(args, libraryArgs(chain, externals)...)
+	return append(args, epilogue...)
+}
+
+// linkSharedArgs assembles the linker arguments for a shared object in the same order as linkArgs.
+func linkSharedArgs(chain *toolchain, flags []string, objects []string, externals []*external, output, soname string) []string {
+	args := append(linkerArgs(chain), "-shared", "-soname", soname, "-o", output)
+	args = append(args, flags...)
+
+	prologue, epilogue := splitStartfiles(chain.sharedStartfiles)
+	args = append(args, prologue...)
+	args = append(args, objects...)
+	args = append(args, libraryArgs(chain, externals)...)
+	return append(args, epilogue...)
+}
+
+// libraryArgs assembles the libraries of the externals and the toolchain with rpaths to their directories.
+func libraryArgs(chain *toolchain, externals []*external) []string {
+	libraries := []string{}
+	rpaths := []string{}
+	for _, ext := range externals {
+		libraries = append(libraries, ext.libraries...)
+		rpaths = append(rpaths, ext.rpaths...)
+	}
+	libraries = append(libraries, chain.stdpplib)
+	libraries = append(libraries, chain.supportlibs...)
+	libraries = append(libraries, chain.stdlib)
+
+	args := []string{}
+	for _, library := range libraries {
+		if library == "" {
+			continue
+		}
+		args = append(args, library)
+		if libraryDir := runtimePath(chain, filepath.Dir(library)); !slices.Contains(rpaths, libraryDir) {
+			rpaths = append(rpaths, libraryDir)
+		}
+	}
+	for _, rpath := range rpaths {
+		args = append(args, "-rpath", rpath)
+	}
+	return args
+}
@@ -0,0 +1,164 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/megakuul/bob/internal/loader"
+	"github.com/megakuul/bob/internal/mod"
+	"github.com/megakuul/bob/internal/mvs"
+	modcfg "github.com/megakuul/bob/pkg/mod"
+)
+
+// module is a loaded bob module, either the root module or one of its direct or transitive includes.
+type module struct {
+	mod *mod.Mod
+	dir string
+	source string
+	revision string
+	remoteToolchain bool
+}
+
+// id identifies the module revision in explanations and errors.
+func (m *module) id() string {
+	if m.revision == "" {
+		return m.mod.Module
+	}
+	return fmt.Sprintf("%s@%s", m.mod.Module, m.revision)
+}
+
+// loadModules fetches all direct and transitive includes of the root module and returns all modules by path.
+// If a module is required in multiple revisions, the revision is chosen with a minimal version selection over
+// all reachable module revisions. Including a module from different repositories is reported as error.
+func (p *Processor) loadModules(root *mod.Mod, rootDir string) (map[string]*module, error) {
+	rootDir, err := filepath.Abs(rootDir)
+	if err!=nil {
+		return nil, err
+	}
+	rootModule := &module{mod: root, dir: rootDir, source: "", revision: "", remoteToolchain: false}
+
+	modules, selections, err := mvs.Resolve(rootModule, root.Module, (*module).id,
+		func(current *module) []mvs.Include {
+			includes := []mvs.Include{}
+			for _, includePath := range slices.Sorted(maps.Keys(current.mod.Includes)) {
+				source := current.mod.Includes[includePath].Source.URL
+				base, revision := loader.SplitRevision(source)
+				includes = append(includes, mvs.Include{
+					Module: includePath, Source: source, Base: base, Revision: revision,
+				})
+			}
+			return includes
+		},
+		func(parent *module, include mvs.Include) (*module, error) {
+			return p.loadModule(parent, include.Module, parent.mod.Includes[include.Module])
+		},
+	)
+	if err!=nil {
+		return nil, err
+	}
+
+	for _, modulePath := range slices.Sorted(maps.Keys(selections)) {
+		if selection := selections[modulePath]; len(selection.Revisions()) > 1 {
+			p.reporter.Info(selection.Explain())
+		}
+	}
+	return modules, nil
+}
+
+// loadModule fetches the source of the include and loads its module file for the platform / arch of the parent.
+func (p *Processor) loadModule(parent *module, includePath string, include mod.Include) (*module, error) {
+	dir, err := p.loadArtifact(include.Source)
+	if err!=nil {
+		return nil, err
+	}
+
+	modCfg, err := modcfg.LoadMod(filepath.Join(dir, modcfg.MOD_FILE_NAME))
+	if err!=nil {
+		return nil, fmt.Errorf("cannot read bob mod: %w", err)
+	}
+	if modCfg.Module != includePath {
+		return nil, fmt.Errorf("source declares module '%s'", modCfg.Module)
+	}
+
+	// includes are only strict if they enable it themselves, problems of third-party modules are reported instead.
+	includeMod, err := mod.CreateMod(modCfg, parent.mod.Target, false)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot load bob mod: %w", err)
+	}
+	if skips := includeMod.Invalid(); len(skips) > 0 {
+		p.reporter.Warn(
+			fmt.Sprintf("skipped '%d' invalid entries of included module", len(skips)),
+			"module", includeMod.Module, "entries", mod.Describe(skips),
+		)
+	}
+
+	_, revision := loader.SplitRevision(include.Source.URL)
+	return &module{
+		mod: includeMod,
+		dir: dir,
+		source: include.Source.URL,
+		revision: revision,
+		remoteToolchain: include.RemoteToolchain,
+	}, nil
+}
+
+// findModule returns the module the import path belongs to. If multiple module paths prefix the import path,
+// the longest one is used.
+func findModule(modules map[string]*module, path string) (*module, error) {
+	var match *module
+	for modulePath, current := range modules {
+		if match != nil && len(modulePath) <= len(match.mod.Module) {
+			continue
+		}
+		if _, ok := packDir(modulePath, "", path); ok {
+			match = current
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("'%s' is not part of any loaded module", path)
+	}
+	return match, nil
+}
+
+// selectToolchain returns the toolchain used to compile the pack. Packs are compiled with the root toolchain
+// (the toolchain of the target), unless they are part of an include with remote toolchain. Those packs use the toolchain of the target
+// declared for the pack in the included module, or the only toolchain the included module provides.
+// Besides the toolchain, the path of the module declaring it is returned (empty for the root module).
+func selectToolchain(owner *module, packPath string, rootChain *mod.Toolchain) (string, *mod.Toolchain, error) {
+	if !owner.remoteToolchain {
+		return "", rootChain, nil
+	}
+	if remoteTarget, ok := owner.mod.Targets[packPath]; ok {
+		return owner.mod.Module, remoteTarget.Toolchain, nil
+	}
+	if len(owner.mod.Toolchains) == 1 {
+		for _, chain := range owner.mod.Toolchains {
+			return owner.mod.Module, &chain, nil
+		}
+	}
+	return "", nil, fmt.Errorf(
+		"module '%s' uses a remote toolchain but neither declares a target for pack '%s' nor a single toolchain",
+		owner.mod.Module, packPath,
+	)
+}
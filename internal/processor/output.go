@@ -0,0 +1,80 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environ returns the environment of the current process with the rpaths of the output prepended to the
+// library search path, so that dynamically linked externals are resolved when the output is executed.
+func (o *Output) Environ() []string {
+	env := os.Environ()
+	if len(o.RPaths) < 1 {
+		return env
+	}
+	libraryPath := strings.Join(o.RPaths, string(filepath.ListSeparator))
+	if current := os.Getenv("LD_LIBRARY_PATH"); current != "" {
+		libraryPath = libraryPath + string(filepath.ListSeparator) + current
+	}
+	return append(env, "LD_LIBRARY_PATH="+libraryPath)
+}
+
+// Install places the output in the directory, missing directories are created. Files are hardlinked or copied
+// if hardlinks are not possible (e.g. across filesystems). Executables are placed directly in the directory,
+// the content of library outputs is placed in the directory. Returns the installed executable or directory.
+func Install(output *Output, dir string) (string, error) {
+	if !output.Library {
+		dst := filepath.Join(dir, filepath.Base(output.Path))
+		return dst, linkOrCopy(output.Path, dst)
+	}
+
+	err := filepath.WalkDir(output.Path, func(path string, entry fs.DirEntry, err error) error {
+		if err!=nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(output.Path, path)
+		if err!=nil {
+			return err
+		}
+		return linkOrCopy(path, filepath.Join(dir, rel))
+	})
+	if err!=nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// linkOrCopy hardlinks $src to $dst replacing existing files, if that fails the file is copied.
+func linkOrCopy(src, dst string) error {
+	if err:=os.MkdirAll(filepath.Dir(dst), 0755); err!=nil {
+		return err
+	}
+	if err:=os.Remove(dst); err!=nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err:=os.Link(src, dst); err==nil {
+		return nil
+	}
+	return copyFile(src, dst)
+}
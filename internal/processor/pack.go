@@ -0,0 +1,235 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/megakuul/bob/internal/graph"
+	"github.com/megakuul/bob/pkg/pack"
+)
+
+// unit contains a loaded pack with all its globbed sources and header directories.
+type unit struct {
+	path string
+	owner *module
+	chain *toolchain
+	dir string
+	cfg *pack.Pack
+	sources []string
+	tests []string
+	includes []string
+	private *usage
+	public *usage
+	pic bool
+}
+
+// usage contains the settings used to compile and link a pack.
+type usage struct {
+	includeDirs []string
+	defines []string
+	compilerFlags []string
+	linkerFlags []string
+}
+
+// external contains the local paths of all downloaded external artifacts.
+type external struct {
+	includeDirs []string
+	libraries []string
+	rpaths []string
+}
+
+// loadPack locates the directory of the pack in its module, reads its pack file and globs its sources.
+func (p *Processor) loadPack(modules map[string]*module, packPath string) (*unit, error) {
+	owner, err := findModule(modules, packPath)
+	if err!=nil {
+		return nil, err
+	}
+	dir, _ := packDir(owner.mod.Module, owner.dir, packPath)
+
+	cfgPath := filepath.Join(dir, pack.PACK_FILE_NAME)
+	cfg, err := pack.LoadPack(cfgPath)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot read bob pack '%s': %w", cfgPath, err)
+	}
+
+	tests, err := glob(dir, cfg.Tests)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot glob tests: %w", err)
+	}
+
+	// tests are only compiled into test executables even if they match the source patterns.
+	sources, err := glob(dir, cfg.Sources)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot glob sources: %w", err)
+	}
+	sources = slices.DeleteFunc(sources, func(source string) bool {
+		return slices.Contains(tests, source)
+	})
+
+	includes, err := glob(dir, cfg.Includes)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot glob includes: %w", err)
+	}
+	// header-only packs compile nothing but still provide their include dirs and public settings.
+	if len(sources) < 1 && len(tests) < 1 && len(includes) < 1 {
+		return nil, fmt.Errorf("pack does not contain any sources or includes")
+	}
+
+	// the pack directory and the directories of its headers are always public.
+	includeDirs := []string{dir}
+	for _, include := range includes {
+		if includeDir := filepath.Dir(include); !slices.Contains(includeDirs, includeDir) {
+			includeDirs = append(includeDirs, includeDir)
+		}
+	}
+	includeDirs = union(includeDirs, resolveDirs(dir, cfg.PublicIncludeDirs))
+
+	return &unit{
+		path: packPath,
+		owner: owner,
+		chain: nil,
+		dir: dir,
+		cfg: cfg,
+		sources: sources,
+		tests: tests,
+		includes: includes,
+		private: &usage{
+			includeDirs: resolveDirs(dir, cfg.IncludeDirs),
+			defines: defineArgs(cfg.Defines),
+			compilerFlags: cfg.CompilerFlags,
+			linkerFlags: cfg.LinkerFlags,
+		},
+		public: &usage{
+			includeDirs: includeDirs,
+			defines: defineArgs(cfg.PublicDefines),
+			compilerFlags: cfg.PublicCompilerFlags,
+			linkerFlags: cfg.PublicLinkerFlags,
+		},
+		pic: false,
+	}, nil
+}
+
+// resolveDirs resolves the directories declared by the pack relative to the pack directory.
+func resolveDirs(dir string, dirs []string) []string {
+	resolved := []string{}
+	for _, includeDir := range dirs {
+		if !filepath.IsAbs(includeDir) {
+			includeDir = filepath.Join(dir, filepath.FromSlash(includeDir))
+		}
+		resolved = append(resolved, includeDir)
+	}
+	return resolved
+}
+
+// defineArgs converts the defines into sorted '-D' compiler arguments. Defines without value are only defined.
+func defineArgs(defines map[string]string) []string {
+	args := []string{}
+	for _, name := range slices.Sorted(maps.Keys(defines)) {
+		if defines[name] == "" {
+			args = append(args, "-D"+name)
+		} else {
+			args = append(args, fmt.Sprintf("-D%s=%s", name, defines[name]))
+		}
+	}
+	return args
+}
+
+// union appends all values of $add that are not yet contained in $values.
+func union(values []string, add []string) []string {
+	values = slices.Clone(values)
+	for _, value := range add {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// packDir returns the directory of the pack if it is part of the module located at $modPath.
+func packDir(modulePath, modPath, packPath string) (string, bool) {
+	if packPath == modulePath {
+		return modPath, true
+	}
+	if rel, ok := strings.CutPrefix(packPath, modulePath+"/"); ok {
+		return filepath.Join(modPath, filepath.FromSlash(rel)), true
+	}
+	return "", false
+}
+
+// loadExternal downloads the headers and libraries of the external referenced as '<module>/@external:<name>'.
+func (p *Processor) loadExternal(modules map[string]*module, path string) (*external, error) {
+	modulePath, name, ok := graph.ParseExternal(path)
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not an external", path)
+	}
+	owner, ok := modules[modulePath]
+	if !ok {
+		return nil, fmt.Errorf("external '%s' references unknown module '%s'", path, modulePath)
+	}
+	modExternal, ok := owner.mod.Externals[name]
+	if !ok {
+		return nil, fmt.Errorf("external '%s' is not defined in module '%s'", name, modulePath)
+	}
+
+	ext := &external{
+		includeDirs: []string{},
+		libraries: []string{},
+		rpaths: modExternal.RPaths,
+	}
+	for _, header := range modExternal.Headers {
+		path, err := p.loadArtifact(header)
+		if err!=nil {
+			return nil, fmt.Errorf("cannot load header of external '%s': %w", name, err)
+		}
+		if includeDir := filepath.Dir(path); !slices.Contains(ext.includeDirs, includeDir) {
+			ext.includeDirs = append(ext.includeDirs, includeDir)
+		}
+	}
+	for _, library := range modExternal.Libraries {
+		path, err := p.loadArtifact(library)
+		if err!=nil {
+			return nil, fmt.Errorf("cannot load library of external '%s': %w", name, err)
+		}
+		ext.libraries = append(ext.libraries, path)
+	}
+	return ext, nil
+}
+
+// glob expands all patterns relative to the directory and returns the matching files without duplicates.
+func glob(dir string, patterns []string) ([]string, error) {
+	files := []string{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err!=nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+		}
+		for _, match := range matches {
+			if !slices.Contains(files, match) {
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
@@ -19,33 +19,230 @@
 
 package processor
 
-import "github.com/megakuul/bob/internal/mod"
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/megakuul/bob/internal/loader"
+	"github.com/megakuul/bob/internal/mod"
+)
+
+// Output describes the artifact produced by a target build. Executables are written to Path, libraries
+// are written into the Path directory together with their headers. RPaths contains the runtime library
+// directories of all externals the target depends on.
+type Output struct {
+	Path string
+	Library bool
+	RPaths []string
+}
 
 type Processor struct {
-	
+	ctx context.Context
+	loader *loader.Loader
+	cachePath string
+	clean bool
+	jobs int
+	keepGoing bool
+	profile *mod.Profile
+	reporter *slog.Logger
 }
 
 type ProcessorOption func(*Processor)
 
 func NewProcessor(opts ...ProcessorOption) *Processor {
-	processor := &Processor{}
+	processor := &Processor{
+		ctx: context.Background(),
+		loader: nil,
+		cachePath: "./.bobcache",
+		clean: false,
+		jobs: runtime.NumCPU(),
+		keepGoing: false,
+		profile: &mod.Profile{Name: mod.DEFAULT_PROFILE},
+		reporter: slog.Default(),
+	}
 
 	for _, opt := range opts {
 		opt(processor)
 	}
 
+	if processor.loader == nil {
+		processor.loader = loader.NewLoader(processor.ctx, loader.WithRootPath(processor.cachePath))
+	}
+
 	return processor
 }
 
+// WithContext defines the context used to download assets and to execute the toolchain.
+func WithContext(ctx context.Context) ProcessorOption {
+	return func(p *Processor) {
+		p.ctx = ctx
+	}
+}
+
+// WithLoader defines a custom loader used to fetch toolchains, includes and externals.
+func WithLoader(l *loader.Loader) ProcessorOption {
+	return func(p *Processor) {
+		p.loader = l
+	}
+}
+
+// WithCachePath defines a custom root path where the processor will output intermediate and final artifacts.
+func WithCachePath(path string) ProcessorOption {
+	return func(p *Processor) {
+		p.cachePath = path
+	}
+}
+
+// WithClean ensures that all cached assets and artifacts are discarded before they are used.
+func WithClean(clean bool) ProcessorOption {
+	return func(p *Processor) {
+		p.clean = clean
+	}
+}
+
+// WithJobs defines the maximum number of toolchain processes executed in parallel.
+func WithJobs(jobs int) ProcessorOption {
+	return func(p *Processor) {
+		p.jobs = max(jobs, 1)
+	}
+}
+
+// WithKeepGoing ensures that a failing pack does not abort the compilation of packs independent of it.
+func WithKeepGoing(keepGoing bool) ProcessorOption {
+	return func(p *Processor) {
+		p.keepGoing = keepGoing
+	}
+}
 
-func (p *Processor) BuildTarget(module *mod.Mod, modPath string, target string) error {
-	// 1. resolve target find all packs that must be processed and all includes / externals that must be present
-	// 2. download and load all includes and perform step 1 adding all dependent includes etc.
-	// 3. now we have a list of externals and a list of toolchains, download them.
-	// 4. now we have a list of packs and external headers all downloaded. Compile them!
-	// 4,5. compile every pack seperate with its depending headers and cache it anywhere.
-	// 5. now we have various objectfiles / libs (compiled packs, external sources, toolchains) use the linker and link all of them in one fat big blob.
-	// 6. pray to god that the linker does not do stupid linker things.
-	// 7. output the final executable
+// WithProfile defines the profile whose flags are added to every compile and link step.
+func WithProfile(profile *mod.Profile) ProcessorOption {
+	return func(p *Processor) {
+		p.profile = profile
+	}
+}
+
+// WithReporter defines the logger used to report decisions taken while resolving modules (e.g. selected revisions).
+func WithReporter(reporter *slog.Logger) ProcessorOption {
+	return func(p *Processor) {
+		p.reporter = reporter
+	}
+}
+
+// BuildTarget builds the specified target of the module located at $modPath. All included modules are loaded,
+// the dependency graph of the target pack is resolved and all packs are compiled concurrently with the toolchain
+// of the target (or the toolchain of their module if it is included with remote toolchain). Finally all objects
+// are linked with the startfiles and libraries of the target toolchain into an executable, or bundled into a
+// static archive / shared object for library targets.
+func (p *Processor) BuildTarget(module *mod.Mod, modPath string, target string) (*Output, error) {
+	modTarget, ok := module.Targets[target]
+	if !ok {
+		return nil, fmt.Errorf("target '%s' is not defined in module '%s'", target, module.Module)
+	}
+
+	if p.clean {
+		if err:=p.cleanup(target); err!=nil {
+			return nil, fmt.Errorf("failed to cleanup cache: %w", err)
+		}
+	}
+
+	pic := modTarget.Library && modTarget.Linkage != mod.LINKAGE_STATIC
+	b, err := p.buildGraph(module, modPath, target, modTarget.Toolchain, pic)
+	if err!=nil {
+		return nil, err
+	}
+
+	if modTarget.Library {
+		outputPath := p.outputPath("lib", target)
+		err = p.buildLibrary(b.chain, &modTarget, b.units[target], b.linkerFlags, b.objects, b.libraries, outputPath)
+		if err!=nil {
+			return nil, fmt.Errorf("failed to build library target '%s': %w", target, err)
+		}
+		return &Output{
+			Path: outputPath,
+			Library: true,
+			RPaths: b.rpaths,
+		}, nil
+	}
+
+	outputPath := p.outputPath("bin", target)
+	err = p.link(b.chain, b.linkerFlags, b.objects, b.libraries, outputPath)
+	if err!=nil {
+		return nil, fmt.Errorf("failed to link target '%s': %w", target, err)
+	}
+
+	return &Output{
+		Path: outputPath,
+		Library: false,
+		RPaths: b.rpaths,
+	}, nil
+}
+
+// TestPath returns the directory containing the test executables of all packs.
+func (p *Processor) TestPath() string {
+	return p.outputPath("test", "")
+}
+
+// outputPath returns the location of an artifact kind (obj, bin, lib, test) of the pack in the cache.
+// Artifacts are separated by profile, so that the artifacts of different profiles coexist.
+func (p *Processor) outputPath(kind, packPath string) string {
+	return filepath.Join(p.cachePath, kind, p.profile.Name, filepath.FromSlash(packPath))
+}
+
+// cleanup removes all intermediate and final artifacts of the target from the cache.
+func (p *Processor) cleanup(target string) error {
+	paths := []string{p.outputPath("obj", path.Join(PIC_DIR, target))}
+	for _, kind := range []string{"obj", "bin", "lib", "test"} {
+		paths = append(paths, p.outputPath(kind, target))
+	}
+	for _, dir := range paths {
+		if err:=os.RemoveAll(dir); err!=nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadArtifact downloads the artifact with the loader and returns the absolute path of the artifact file.
+// Artifacts without url are considered unset and result in an empty path.
+func (p *Processor) loadArtifact(artifact mod.Artifact) (string, error) {
+	if artifact.URL == "" {
+		return "", nil
+	}
+	typ, err := loader.ParseType(artifact.URL)
+	if err!=nil {
+		return "", err
+	}
+	dir, err := p.loader.Load(typ, artifact.URL, p.clean)
+	if err!=nil {
+		return "", fmt.Errorf("failed to load '%s': %w", artifact.URL, err)
+	}
+	// local directories are symlinked into the cache, resolving them avoids that
+	// outputs (e.g. rpaths or the interpreter) reference the cache location.
+	dir, err = filepath.EvalSymlinks(dir)
+	if err!=nil {
+		return "", err
+	}
+	path, err := filepath.Abs(filepath.Join(dir, artifact.Path))
+	if err!=nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// execute runs the specified toolchain binary and attaches its combined output to the error if it fails.
+func (p *Processor) execute(ctx context.Context, name string, args ...string) error {
+	slog.Debug(fmt.Sprintf("executing '%s %s'", name, strings.Join(args, " ")))
+
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err!=nil {
+		return fmt.Errorf("'%s' failed: %w\n%s", filepath.Base(name), err, strings.TrimSpace(string(output)))
+	}
 	return nil
 }
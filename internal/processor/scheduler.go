@@ -0,0 +1,116 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/megakuul/bob/internal/graph"
+	"golang.org/x/sync/errgroup"
+)
+
+// task tracks the compilation state of a graph node. The fields are written before $done is closed.
+type task struct {
+	done chan struct{}
+	failed bool
+	objects []string
+}
+
+// compileGraph compiles all packs of the graph concurrently and returns their objects in topological order.
+// A pack is started as soon as all of its dependencies are compiled, the translation units of all started
+// packs share $jobs workers. The first error cancels all running jobs unless keepGoing is enabled, in which
+// case all independent packs are still compiled and the errors are reported together.
+func (p *Processor) compileGraph(depGraph *graph.Graph, units map[string]*unit, externals map[string]*external) ([]string, error) {
+	group, ctx := errgroup.WithContext(p.ctx)
+	workers := make(chan struct{}, p.jobs)
+
+	errsLock := sync.Mutex{}
+	errs := []error{}
+
+	tasks := map[*graph.Node]*task{}
+	for _, node := range depGraph.Order() {
+		tasks[node] = &task{done: make(chan struct{})}
+	}
+
+	for _, node := range depGraph.Order() {
+		group.Go(func() error {
+			t := tasks[node]
+			defer close(t.done)
+
+			for _, dep := range node.Deps {
+				select {
+				case <-tasks[dep].done:
+				case <-ctx.Done():
+					t.failed = true
+					return ctx.Err()
+				}
+				// dependents of failed packs are skipped, the failure itself is already reported.
+				if tasks[dep].failed {
+					t.failed = true
+					return nil
+				}
+			}
+			if node.Type != graph.NODE_PACK {
+				return nil
+			}
+
+			objects, err := p.compilePack(ctx, workers, units[node.Path], collectUsage(depGraph, node, units, externals))
+			if err!=nil {
+				t.failed = true
+				err = fmt.Errorf("failed to compile pack '%s': %w", node.Path, err)
+				if !p.keepGoing {
+					return err
+				}
+				errsLock.Lock()
+				errs = append(errs, err)
+				errsLock.Unlock()
+				return nil
+			}
+			t.objects = objects
+			return nil
+		})
+	}
+
+	if err:=group.Wait(); err!=nil {
+		return nil, err
+	}
+	if err:=errors.Join(errs...); err!=nil {
+		return nil, err
+	}
+
+	objects := []string{}
+	for _, node := range depGraph.Order() {
+		objects = append(objects, tasks[node].objects...)
+	}
+	return objects, nil
+}
+
+// acquire blocks until a worker slot is available. The returned function releases the slot.
+func acquire(ctx context.Context, workers chan struct{}) (func(), error) {
+	select {
+	case workers <- struct{}{}:
+		return func() { <-workers }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,128 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/megakuul/bob/internal/mod"
+	"golang.org/x/sync/errgroup"
+)
+
+// BuildTests builds every test source of the pack into a separate test executable with the toolchain.
+// Test executables contain the objects of the pack and all its dependencies and are placed at
+// $cachePath/test/$profile/$pack/$test. The object defining 'main' of executable packs is excluded, as
+// the test source defines its own entry point.
+func (p *Processor) BuildTests(module *mod.Mod, modPath string, packPath string, chain *mod.Toolchain) ([]*Output, error) {
+	if p.clean {
+		if err:=p.cleanup(packPath); err!=nil {
+			return nil, fmt.Errorf("failed to cleanup cache: %w", err)
+		}
+	}
+
+	b, err := p.buildGraph(module, modPath, packPath, chain, false)
+	if err!=nil {
+		return nil, err
+	}
+
+	objects := []string{}
+	for _, object := range b.objects {
+		isMain, err := definesMain(object)
+		if err!=nil {
+			return nil, fmt.Errorf("failed to inspect object '%s': %w", object, err)
+		}
+		if !isMain {
+			objects = append(objects, object)
+		}
+	}
+
+	u := b.units[packPath]
+	testUnit := *u
+	testUnit.sources = u.tests
+	testObjects, err := p.compilePack(
+		p.ctx, make(chan struct{}, p.jobs), &testUnit, collectUsage(b.graph, b.graph.Root, b.units, b.externals),
+	)
+	if err!=nil {
+		return nil, fmt.Errorf("failed to compile tests of pack '%s': %w", packPath, err)
+	}
+
+	outputs := make([]*Output, len(testObjects))
+	group := errgroup.Group{}
+	group.SetLimit(p.jobs)
+	for i, testObject := range testObjects {
+		group.Go(func() error {
+			// tests are named by their path in the pack, so that equally named tests in subdirectories coexist.
+			rel, err := filepath.Rel(u.dir, u.tests[i])
+			if err!=nil {
+				return err
+			}
+			name := strings.TrimSuffix(rel, filepath.Ext(rel))
+			outputPath := filepath.Join(p.outputPath("test", packPath), name)
+			err = p.link(b.chain, b.linkerFlags, append(slices.Clone(objects), testObject), b.libraries, outputPath)
+			if err!=nil {
+				return fmt.Errorf("failed to link test '%s': %w", filepath.ToSlash(name), err)
+			}
+			outputs[i] = &Output{
+				Path: outputPath,
+				Library: false,
+				RPaths: b.rpaths,
+			}
+			return nil
+		})
+	}
+	if err:=group.Wait(); err!=nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// definesMain checks if the object defines the 'main' symbol. Objects in formats other than ELF and Mach-O
+// are assumed to not define it.
+func definesMain(object string) (bool, error) {
+	if elfFile, err := elf.Open(object); err==nil {
+		defer elfFile.Close()
+		symbols, err := elfFile.Symbols()
+		if errors.Is(err, elf.ErrNoSymbols) {
+			return false, nil
+		} else if err!=nil {
+			return false, err
+		}
+		return slices.ContainsFunc(symbols, func(symbol elf.Symbol) bool {
+			return symbol.Name == "main" && symbol.Section != elf.SHN_UNDEF &&
+				elf.ST_BIND(symbol.Info) != elf.STB_LOCAL
+		}), nil
+	}
+	if machoFile, err := macho.Open(object); err==nil {
+		defer machoFile.Close()
+		if machoFile.Symtab == nil {
+			return false, nil
+		}
+		return slices.ContainsFunc(machoFile.Symtab.Syms, func(symbol macho.Symbol) bool {
+			return symbol.Name == "_main" && symbol.Sect != 0
+		}), nil
+	}
+	return false, nil
+}
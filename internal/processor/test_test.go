@@ -0,0 +1,97 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/megakuul/bob/internal/mod"
+	modcfg "github.com/megakuul/bob/pkg/mod"
+)
+
+// TestBuildTestsExecutablePack builds the tests of an executable pack with the host toolchain. The tests define
+// their own 'main' and equally named tests are located in different subdirectories.
+func TestBuildTestsExecutablePack(t *testing.T) {
+	target, err := mod.PlatformTriple(runtime.GOOS, runtime.GOARCH)
+	if err!=nil {
+		t.Skipf("unsupported host: %v", err)
+	}
+	module, err := mod.CreateMod(&modcfg.Mod{
+		Module: "example.com/test",
+		Toolchains: []modcfg.Toolchain{{Name: "host", Auto: true}},
+	}, *target, false)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	chain, ok := module.Toolchains["host"]
+	if !ok {
+		t.Skip("no host toolchain available")
+	}
+
+	modPath := t.TempDir()
+	files := map[string]string{
+		"app/bob.pack.toml": "std = \"20\"\nsources = [\"*.cpp\"]\n" +
+			"tests = [\"*_test.cpp\", \"a/*_test.cpp\", \"b/*_test.cpp\"]\n",
+		"app/add.cpp": "int add(int a, int b) { return a + b; }\n",
+		"app/main.cpp": "int add(int a, int b);\nint main() { return add(1, 2) == 3 ? 0 : 1; }\n",
+		"app/add_test.cpp": "int add(int a, int b);\nint main() { return add(2, 2) == 4 ? 0 : 1; }\n",
+		"app/a/x_test.cpp": "int main() { return 0; }\n",
+		"app/b/x_test.cpp": "int main() { return 0; }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(modPath, filepath.FromSlash(name))
+		if err:=os.MkdirAll(filepath.Dir(path), 0755); err!=nil {
+			t.Fatal(err)
+		}
+		if err:=os.WriteFile(path, []byte(content), 0644); err!=nil {
+			t.Fatal(err)
+		}
+	}
+
+	proc := NewProcessor(WithCachePath(filepath.Join(modPath, ".bobcache")))
+	outputs, err := proc.BuildTests(module, modPath, "example.com/test/app", &chain)
+	if err!=nil {
+		t.Fatalf("failed to build tests: %v", err)
+	}
+
+	names := []string{}
+	for _, output := range outputs {
+		name, err := filepath.Rel(proc.outputPath("test", "example.com/test/app"), output.Path)
+		if err!=nil {
+			t.Fatal(err)
+		}
+		names = append(names, filepath.ToSlash(name))
+
+		cmd := exec.Command(output.Path)
+		cmd.Env = output.Environ()
+		if out, err := cmd.CombinedOutput(); err!=nil {
+			t.Errorf("test '%s' failed: %v\n%s", name, err, out)
+		}
+	}
+	slices.Sort(names)
+	if expected := []string{"a/x_test", "add_test", "b/x_test"}; !slices.Equal(names, expected) {
+		t.Errorf("expected tests '%v' got '%v'", expected, names)
+	}
+}
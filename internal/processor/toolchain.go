@@ -0,0 +1,214 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package processor
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/megakuul/bob/internal/loader"
+	"github.com/megakuul/bob/internal/mod"
+)
+
+// toolchain contains the local paths of all downloaded toolchain artifacts.
+type toolchain struct {
+	identity string
+	flavor mod.FLAVOR
+	target string
+	sysroot string
+	compiler string
+	linker string
+	interpreter string
+	archiver string
+	stdlib string
+	stdpplib string
+	supportlibs []string
+	startfiles []string
+	sharedStartfiles []string
+}
+
+// loadToolchain downloads all artifacts of the toolchain.
+func (p *Processor) loadToolchain(chain *mod.Toolchain) (*toolchain, error) {
+	sysroot, err := p.loadArtifact(chain.Sysroot)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot load sysroot: %w", err)
+	}
+
+	compiler, err := p.loadChainArtifact(sysroot, chain.Compiler)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot load compiler: %w", err)
+	}
+	if compiler == "" {
+		return nil, fmt.Errorf("toolchain does not specify a compiler")
+	}
+	compilerHash, err := loader.HashFile(compiler)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot hash compiler: %w", err)
+	}
+
+	linker, err := p.loadChainArtifact(sysroot, chain.Linker)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot load linker: %w", err)
+	}
+	if linker == "" {
+		return nil, fmt.Errorf("toolchain does not specify a linker")
+	}
+
+	interpreter, err := p.loadChainArtifact(sysroot, chain.Interpreter)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot load interpreter: %w", err)
+	}
+
+	archiver, err := p.loadChainArtifact(sysroot, chain.Archiver)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot load archiver: %w", err)
+	}
+
+	stdlib, err := p.loadChainArtifact(sysroot, chain.Stdlib)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot load stdlib: %w", err)
+	}
+
+	stdpplib, err := p.loadChainArtifact(sysroot, chain.Stdpplib)
+	if err!=nil {
+		return nil, fmt.Errorf("cannot load std++lib: %w", err)
+	}
+
+	supportlibs := []string{}
+	for _, lib := range chain.Supportlibs {
+		path, err := p.loadChainArtifact(sysroot, lib)
+		if err!=nil {
+			return nil, fmt.Errorf("cannot load supportlib: %w", err)
+		}
+		supportlibs = append(supportlibs, path)
+	}
+
+	startfiles := []string{}
+	for _, file := range chain.Startfiles {
+		path, err := p.loadChainArtifact(sysroot, file)
+		if err!=nil {
+			return nil, fmt.Errorf("cannot load startfile: %w", err)
+		}
+		startfiles = append(startfiles, path)
+	}
+
+	sharedStartfiles := []string{}
+	for _, file := range chain.SharedStartfiles {
+		path, err := p.loadChainArtifact(sysroot, file)
+		if err!=nil {
+			return nil, fmt.Errorf("cannot load shared startfile: %w", err)
+		}
+		sharedStartfiles = append(sharedStartfiles, path)
+	}
+
+	return &toolchain{
+		identity: fmt.Sprintf("%s@%s", compiler, compilerHash),
+		flavor: chain.Flavor,
+		target: chain.Target,
+		sysroot: sysroot,
+		compiler: compiler,
+		linker: linker,
+		interpreter: interpreter,
+		archiver: archiver,
+		stdlib: stdlib,
+		stdpplib: stdpplib,
+		supportlibs: supportlibs,
+		startfiles: startfiles,
+		sharedStartfiles: sharedStartfiles,
+	}, nil
+}
+
+// loadChainArtifact loads an artifact of the toolchain. Artifacts without url are located inside the sysroot.
+func (p *Processor) loadChainArtifact(sysroot string, artifact mod.Artifact) (string, error) {
+	if artifact.URL == "" && artifact.Path != "" && sysroot != "" {
+		return filepath.Join(sysroot, artifact.Path), nil
+	}
+	return p.loadArtifact(artifact)
+}
+
+// runtimePath returns the path of a toolchain file on the target system. Files inside the sysroot are located
+// relative to the root of the target system, other files are expected at the same location as on the host.
+func runtimePath(chain *toolchain, path string) string {
+	if chain.sysroot == "" {
+		return path
+	}
+	rel, err := filepath.Rel(chain.sysroot, path)
+	if err!=nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.Join(string(filepath.Separator), rel)
+}
+
+// sysrootArgs returns the arguments pointing the compiler or linker to the sysroot of the toolchain.
+func sysrootArgs(chain *toolchain) []string {
+	if chain.sysroot == "" {
+		return nil
+	}
+	return []string{"--sysroot=" + chain.sysroot}
+}
+
+// flavorArgs returns the arguments selecting the target and the C++ standard library of clang toolchains.
+// Gcc toolchains are built for a single target and standard library, hence they do not require arguments.
+func flavorArgs(chain *toolchain) []string {
+	if chain.flavor != mod.FLAVOR_CLANG {
+		return nil
+	}
+	args := []string{}
+	if chain.target != "" {
+		args = append(args, "--target="+chain.target)
+	}
+	if strings.HasPrefix(filepath.Base(chain.stdpplib), "libc++") {
+		args = append(args, "-stdlib=libc++")
+	}
+	return args
+}
+
+// linkerArgs returns the arguments required by the linker before any other argument.
+// The generic 'lld' driver must be told to act as the gnu linker (when not invoked as 'ld.lld')
+// and library search paths must be resolved inside the sysroot.
+func linkerArgs(chain *toolchain) []string {
+	if filepath.Base(chain.linker) == "lld" {
+		return append([]string{"-flavor", "gnu"}, sysrootArgs(chain)...)
+	}
+	return sysrootArgs(chain)
+}
+
+// toolchainKey identifies a toolchain by the module declaring it and its name.
+func toolchainKey(module, name string) string {
+	return fmt.Sprintf("%s/%s", module, name)
+}
+
+// splitStartfiles splits the startfiles into the files linked before the objects (crt1.o, crti.o, crtbegin.o)
+// and the files linked after all objects and libraries (crtend.o, crtn.o).
+func splitStartfiles(startfiles []string) (prologue []string, epilogue []string) {
+	ends, terminators := []string{}, []string{}
+	for _, file := range startfiles {
+		switch name := filepath.Base(file); {
+		case strings.HasPrefix(name, "crtend"):
+			ends = append(ends, file)
+		case strings.HasPrefix(name, "crtn"):
+			terminators = append(terminators, file)
+		default:
+			prologue = append(prologue, file)
+		}
+	}
+	return prologue, append(ends, terminators...)
+}
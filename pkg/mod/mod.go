@@ -21,6 +21,7 @@ package mod
 
 import (
 	"github.com/BurntSushi/toml"
+	"github.com/megakuul/bob/pkg/schema"
 	"os"
 )
 
@@ -33,7 +34,7 @@ func LoadMod(path string) (*Mod, error) {
 	}
 
 	mod := &Mod{}
-	_, err = toml.Decode(string(rawMod), mod)
+	err = schema.Decode(string(rawMod), mod)
 	if err != nil {
 		return nil, err
 	}
@@ -41,12 +42,26 @@ func LoadMod(path string) (*Mod, error) {
 	return mod, nil
 }
 
+func WriteMod(path string, mod *Mod) error {
+	modFile, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer modFile.Close()
+
+	encoder := toml.NewEncoder(modFile)
+	encoder.Indent = ""
+	return encoder.Encode(mod)
+}
+
 type Mod struct {
 	Module string `toml:"module"`
+	Strict bool `toml:"strict,omitempty"`
 	Toolchains []Toolchain `toml:"toolchains"`
 	Targets []Target `toml:"targets"`
 	Includes []Include `toml:"includes"`
 	Externals []External `toml:"externals"`
+	Profiles []Profile `toml:"profiles"`
 }
 
 type Path struct {
@@ -56,20 +71,28 @@ type Path struct {
 
 type Toolchain struct {
 	Name string `toml:"name"`
+	Auto bool `toml:"auto,omitempty"`
+	Flavor string `toml:"flavor,omitempty"`
+	Target string `toml:"target,omitempty"`
 	Platforms []string `toml:"platforms"`
 	Archs []string `toml:"archs"`
 	
+	Sysroot *Path `toml:"sysroot,omitempty"`
 	Compiler Path `toml:"compiler"`
 	Linker Path `toml:"linker"`
+	Interpreter Path `toml:"interpreter"`
+	Archiver Path `toml:"archiver"`
 	Stdlib Path `toml:"stdlib"`
 	Stdpplib Path `toml:"stdpplib"`
 	Supportlibs []Path `toml:"supportlibs"`
 	Startfiles []Path `toml:"startfiles"`
+	SharedStartfiles []Path `toml:"shared_startfiles"`
 }
 
 type Target struct {
 	Pack string `toml:"pack"`
 	Library bool `toml:"library"`
+	Linkage string `toml:"linkage"`
 	Toolchains []string `toml:"toolchains"`
 }
 
@@ -85,3 +108,9 @@ type External struct {
 	Headers []Path `toml:"headers"`
 	Libraries []Path `toml:"libraries"`
 }
+
+type Profile struct {
+	Name string `toml:"name"`
+	CompilerFlags []string `toml:"compiler_flags"`
+	LinkerFlags []string `toml:"linker_flags"`
+}
@@ -21,9 +21,12 @@ package pack
 
 import (
 	"github.com/BurntSushi/toml"
+	"github.com/megakuul/bob/pkg/schema"
 	"os"
 )
 
+const PACK_FILE_NAME = "bob.pack.toml"
+
 func LoadPack(path string) (*Pack, error) {
 	rawPack, err := os.ReadFile(path)
 	if err != nil {
@@ -31,7 +34,7 @@ func LoadPack(path string) (*Pack, error) {
 	}
 
 	pack := &Pack{}
-	_, err = toml.Decode(string(rawPack), pack)
+	err = schema.Decode(string(rawPack), pack)
 	if err != nil {
 		return nil, err
 	}
@@ -39,6 +42,18 @@ func LoadPack(path string) (*Pack, error) {
 	return pack, nil
 }
 
+func WritePack(path string, pack *Pack) error {
+	packFile, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer packFile.Close()
+
+	encoder := toml.NewEncoder(packFile)
+	encoder.Indent = ""
+	return encoder.Encode(pack)
+}
+
 type STD_LIB string
 
 const (
@@ -49,10 +64,20 @@ const (
 	STD_C23 STD_LIB = "23"
 )
 
+// Pack describes a single pack. Compiler flags, linker flags, defines and include directories only apply to the
+// pack itself, while their public variants also apply to all packs depending on it (directly or transitively).
 type Pack struct {
-	Std           STD_LIB  `toml:"std"`
-	CompilerFlags []string `toml:"compiler_flags"`
-	Includes      []string `toml:"includes"`
-	Sources       []string `toml:"sources"`
-	Deps          []string `toml:"deps"`
+	Std                 STD_LIB           `toml:"std"`
+	CompilerFlags       []string          `toml:"compiler_flags"`
+	LinkerFlags         []string          `toml:"linker_flags"`
+	Defines             map[string]string `toml:"defines"`
+	IncludeDirs         []string          `toml:"include_dirs"`
+	PublicCompilerFlags []string          `toml:"public_compiler_flags"`
+	PublicLinkerFlags   []string          `toml:"public_linker_flags"`
+	PublicDefines       map[string]string `toml:"public_defines"`
+	PublicIncludeDirs   []string          `toml:"public_include_dirs"`
+	Includes            []string          `toml:"includes"`
+	Sources             []string          `toml:"sources"`
+	Tests               []string          `toml:"tests"`
+	Deps                []string          `toml:"deps"`
 }
@@ -0,0 +1,168 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type position struct {
+	line int
+	column int
+}
+
+var indicesPattern = regexp.MustCompile(`\[\d+\]`)
+
+// locate scans the document for table headers and keys and returns the position of every field path.
+// Paths inside array tables contain the table index (e.g. 'toolchains[1].name') and are additionally
+// stored without indices for the first occurrence. Keys inside inline tables and array values are not located.
+func locate(data string) map[string]position {
+	positions := map[string]position{}
+	record := func(path string, pos position) {
+		positions[path] = pos
+		if plain := indicesPattern.ReplaceAllString(path, ""); plain != path {
+			if _, ok := positions[plain]; !ok {
+				positions[plain] = pos
+			}
+		}
+	}
+
+	arrays := map[string]int{}
+	table := ""
+	depth := 0
+	multiline := ""
+	for i, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		pos := position{line: i+1, column: len(line)-len(trimmed)+1}
+
+		if multiline != "" {
+			if strings.Count(line, multiline)%2 == 1 {
+				multiline = ""
+			}
+			continue
+		}
+		if depth > 0 {
+			depth += brackets(line)
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "[["):
+			segments, _ := splitKey(trimmed[2:], ']')
+			if len(segments) < 1 {
+				continue
+			}
+			array := join(resolve(arrays, segments[:len(segments)-1]), segments[len(segments)-1])
+			arrays[array]++
+			table = fmt.Sprintf("%s[%d]", array, arrays[array]-1)
+			record(table, pos)
+		case strings.HasPrefix(trimmed, "["):
+			segments, _ := splitKey(trimmed[1:], ']')
+			table = resolve(arrays, segments)
+			record(table, pos)
+		default:
+			segments, value := splitKey(trimmed, '=')
+			if len(segments) < 1 {
+				continue
+			}
+			record(join(table, strings.Join(segments, ".")), pos)
+			for _, quote := range []string{`"""`, `'''`} {
+				if strings.Count(value, quote)%2 == 1 {
+					multiline = quote
+				}
+			}
+			depth = brackets(value)
+		}
+	}
+	return positions
+}
+
+// resolve joins the header segments to a path, adding the index of the last table to every array table.
+func resolve(arrays map[string]int, segments []string) string {
+	path := ""
+	for _, segment := range segments {
+		path = join(path, segment)
+		if count, ok := arrays[path]; ok {
+			path = fmt.Sprintf("%s[%d]", path, count-1)
+		}
+	}
+	return path
+}
+
+// splitKey splits the dotted key at the start of the line until the terminator and returns the remaining line.
+// Quoted segments may contain dots and terminators.
+func splitKey(line string, terminator byte) ([]string, string) {
+	segments := []string{}
+	current := strings.Builder{}
+	quote := byte(0)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				current.WriteByte(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.' || c == terminator:
+			segments = append(segments, strings.TrimSpace(current.String()))
+			current.Reset()
+			if c == terminator {
+				return segments, line[i+1:]
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	return nil, ""
+}
+
+// brackets returns the difference of opening and closing brackets outside of strings and comments.
+func brackets(line string) int {
+	count := 0
+	quote := byte(0)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return count
+		case c == '[':
+			count++
+		case c == ']':
+			count--
+		}
+	}
+	return count
+}
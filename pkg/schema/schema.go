@@ -0,0 +1,288 @@
+/**
+ * Bob Build System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package schema
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Error describes a violation of the schema at a position of the document.
+type Error struct {
+	Field string
+	Line int
+	Column int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Line < 1 {
+		return fmt.Sprintf("field '%s': %s", e.Field, e.Message)
+	}
+	if e.Field == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: field '%s': %s", e.Line, e.Column, e.Field, e.Message)
+}
+
+// Decode decodes the toml document into v after validating it against the schema defined by the toml tags of v.
+// Values with the wrong type and keys without corresponding field are rejected. All violations are reported
+// together with their field path, position and the expected type.
+func Decode(data string, v any) error {
+	raw := map[string]any{}
+	if _, err := toml.Decode(data, &raw); err!=nil {
+		return syntaxError(data, err)
+	}
+
+	positions := locate(data)
+	if errs := validate(raw, reflect.TypeOf(v), "", positions); len(errs) > 0 {
+		return joinErrors(errs)
+	}
+
+	_, err := toml.Decode(data, v)
+	return err
+}
+
+// joinErrors joins the errors ordered by their position in the document.
+func joinErrors(errs []*Error) error {
+	slices.SortStableFunc(errs, func(a, b *Error) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column), strings.Compare(a.Field, b.Field))
+	})
+	joined := []error{}
+	for _, err := range errs {
+		joined = append(joined, err)
+	}
+	return errors.Join(joined...)
+}
+
+// syntaxError converts the toml parse error into a positioned error.
+func syntaxError(data string, err error) error {
+	parseErr := toml.ParseError{}
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+	message := parseErr.Message
+	if message == "" {
+		// errors created by the lexer are only available in the formatted message.
+		message = parseErr.Error()
+		if _, detail, ok := strings.Cut(message, "): "); ok && parseErr.LastKey != "" {
+			message = detail
+		} else if _, detail, ok := strings.Cut(message, fmt.Sprintf("line %d: ", parseErr.Position.Line)); ok {
+			message = detail
+		}
+	}
+	lineStart := strings.LastIndex(data[:min(parseErr.Position.Start, len(data))], "\n") + 1
+	return &Error{
+		Field: parseErr.LastKey,
+		Line: parseErr.Position.Line,
+		Column: parseErr.Position.Start - lineStart + 1,
+		Message: message,
+	}
+}
+
+// validate checks the decoded value against the type and returns an error for every mismatch and every key
+// without corresponding field.
+func validate(value any, typ reflect.Type, path string, positions map[string]position) []*Error {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	mismatch := func() []*Error {
+		return []*Error{newError(path, positions, fmt.Sprintf(
+			"expected %s got %s", typeName(typ), valueName(value),
+		))}
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface:
+		return nil
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return mismatch()
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return mismatch()
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, ok := value.(int64); !ok {
+			return mismatch()
+		}
+	case reflect.Float32, reflect.Float64:
+		switch value.(type) {
+		case float64, int64:
+		default:
+			return mismatch()
+		}
+	case reflect.Slice, reflect.Array:
+		elements := reflect.ValueOf(value)
+		if elements.Kind() != reflect.Slice {
+			return mismatch()
+		}
+		errs := []*Error{}
+		for i := 0; i < elements.Len(); i++ {
+			errs = append(errs, validate(
+				elements.Index(i).Interface(), typ.Elem(), fmt.Sprintf("%s[%d]", path, i), positions,
+			)...)
+		}
+		return errs
+	case reflect.Map:
+		table, ok := value.(map[string]any)
+		if !ok {
+			return mismatch()
+		}
+		errs := []*Error{}
+		for key, entry := range table {
+			errs = append(errs, validate(entry, typ.Elem(), join(path, key), positions)...)
+		}
+		return errs
+	case reflect.Struct:
+		if typ == reflect.TypeOf(time.Time{}) {
+			if _, ok := value.(time.Time); !ok {
+				return mismatch()
+			}
+			return nil
+		}
+		table, ok := value.(map[string]any)
+		if !ok {
+			return mismatch()
+		}
+		fields := fieldTypes(typ)
+		errs := []*Error{}
+		for key, entry := range table {
+			fieldType, ok := fields[key]
+			if !ok {
+				errs = append(errs, newError(join(path, key), positions, "unknown field"))
+				continue
+			}
+			errs = append(errs, validate(entry, fieldType, join(path, key), positions)...)
+		}
+		return errs
+	}
+	return nil
+}
+
+// fieldTypes maps the toml key of every field of the struct to its type.
+func fieldTypes(typ reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// typeName describes the type in toml terms (e.g. 'array of string').
+func typeName(typ reflect.Type) string {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Slice, reflect.Array:
+		return "array of " + typeName(typ.Elem())
+	case reflect.Map:
+		return "table of " + typeName(typ.Elem())
+	case reflect.Struct:
+		if typ == reflect.TypeOf(time.Time{}) {
+			return "datetime"
+		}
+		return "table"
+	}
+	return typ.String()
+}
+
+// valueName describes the toml type of a decoded value.
+func valueName(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int64:
+		return "integer"
+	case float64:
+		return "float"
+	case time.Time:
+		return "datetime"
+	case map[string]any:
+		return "table"
+	}
+	if reflect.ValueOf(value).Kind() == reflect.Slice {
+		return "array"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+var indexPattern = regexp.MustCompile(`\[\d+\]$`)
+
+// newError creates an error positioned at the field. Fields that cannot be located (e.g. array elements)
+// are positioned at their closest located parent.
+func newError(field string, positions map[string]position, message string) *Error {
+	err := &Error{Field: field, Message: message}
+	for path := field; path != ""; {
+		if pos, ok := positions[path]; ok {
+			err.Line, err.Column = pos.line, pos.column
+			break
+		}
+		if trimmed := indexPattern.ReplaceAllString(path, ""); trimmed != path {
+			path = trimmed
+		} else if i := strings.LastIndex(path, "."); i >= 0 {
+			path = path[:i]
+		} else {
+			break
+		}
+	}
+	return err
+}
+
+// join appends the key to the field path.
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
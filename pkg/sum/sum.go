@@ -24,6 +24,8 @@ import (
 	"os"
 )
 
+const SUM_FILE_NAME = "bob.sum.toml"
+
 func LoadSum(path string) (*Sum, error) {
 	rawSum, err := os.ReadFile(path)
 	if err != nil {
@@ -36,9 +38,29 @@ func LoadSum(path string) (*Sum, error) {
 		return nil, err
 	}
 
+	if sum.Paths == nil {
+		sum.Paths = map[string]string{}
+	}
+
 	return sum, nil
 }
 
+func NewSum() *Sum {
+	return &Sum{
+		Paths: map[string]string{},
+	}
+}
+
+func WriteSum(path string, sum *Sum) error {
+	sumFile, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer sumFile.Close()
+
+	return toml.NewEncoder(sumFile).Encode(sum)
+}
+
 type Sum struct {
 	Paths map[string]string `toml:"paths"`
 }